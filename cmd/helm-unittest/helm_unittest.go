@@ -1,15 +1,18 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/helm-unittest/helm-unittest/internal/common"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/formatter"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
 	"github.com/spf13/cobra"
 )
 
@@ -18,22 +21,66 @@ type testOptions struct {
 	debugLogging   bool
 	useFailfast    bool
 	useStrict      bool
+	deterministic  bool
 	colored        bool
 	updateSnapshot bool
+	pruneSnapshots bool
+	ci             bool
+	lineDiff       bool
 	withSubChart   bool
 	testFiles      []string
 	valuesFiles    []string
 	outputFile     string
 	outputType     string
 	chartTestsPath string
+	snapshotLayout string
+	renderPath     string
+	renderDiff     bool
+	// colorConfigured the color is set in the project configuration file
+	colorConfigured bool
+}
+
+// scaffoldOptions stores options of the scaffold command setup by user in command line
+type scaffoldOptions struct {
+	withSnapshot bool
+	overwrite    bool
+}
+
+// fmtOptions stores options of the fmt command setup by user in command line
+type fmtOptions struct {
+	check bool
+}
+
+// explainOptions stores options of the explain command setup by user in command line
+type explainOptions struct {
+	suite string
+	test  string
+}
+
+// fuzzOptions stores options of the fuzz command setup by user in command line
+type fuzzOptions struct {
+	seed         int64
+	iterations   int
+	failuresPath string
 }
 
 var defaultFilePattern = filepath.Join("tests", "*_test.yaml")
 
 var testConfig = testOptions{}
 
+var scaffoldConfig = scaffoldOptions{}
+
+var fmtConfig = fmtOptions{}
+
+var explainConfig = explainOptions{}
+
+var fuzzConfig = fuzzOptions{}
+
 var testRunner = unittest.TestRunner{}
 
+// projectConfigPath the project configuration file used by the command, empty when none is found
+var projectConfigPath string
+
 var cmd = &cobra.Command{
 	Use:   "unittest [flags] CHART [...]",
 	Short: "unittest for helm charts",
@@ -72,15 +119,429 @@ details about how to write tests.
 	Run:  RunPlugin,
 }
 
+var reviewCmd = &cobra.Command{
+	Use:   "review [flags] CHART [...]",
+	Short: "review the changed and new snapshots",
+	Long: `Running chart unittest and review the changed and new snapshots.
+
+The diff of each changed or new snapshot is shown, and the
+snapshot can be accepted, rejected or skipped. Only the
+accepted snapshots are stored, a rejected snapshot fails
+the review.
+
+$ helm unittest review my-chart
+`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  RunReview,
+}
+
+var convertSnapshotsCmd = &cobra.Command{
+	Use:   "convert-snapshots [flags] CHART [...]",
+	Short: "convert the snapshots to the snapshot layout",
+	Long: `Running chart unittest and store the snapshots in the layout
+of --snapshot-layout, the snapshots in the other layout are
+removed. The tests are run to find the document of each
+snapshot, the content of the snapshots is not changed.
+
+$ helm unittest convert-snapshots --snapshot-layout document my-chart
+`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  RunConvertSnapshots,
+}
+
+var scaffoldCmd = &cobra.Command{
+	Use:   "scaffold [flags] CHART [...]",
+	Short: "scaffold the test suites of the templates",
+	Long: `Render the chart with the default values and write a test
+suite for each template into the "tests" directory of the
+chart. The assertions check the kind, apiVersion, name,
+replicas, images and ports of the rendered documents.
+Existing test suites are skipped.
+
+$ helm unittest scaffold my-chart
+`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  RunScaffold,
+}
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [flags] CHART [...]",
+	Short: "lint the test suites without running the tests",
+	Long: `Check the test suite files of the charts without running
+the tests. The test suites are validated against the schema
+of the test suite files, and checked for templates and
+values files which do not exist, duplicate test names,
+assertions without effect and document indices out of
+range of the rendered documents.
+
+$ helm unittest lint my-chart
+`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  RunLint,
+}
+
+var fmtCmd = &cobra.Command{
+	Use:   "fmt [flags] CHART [...]",
+	Short: "format the test suite files",
+	Long: `Format the test suite files of the charts with an
+indentation of two spaces and sequences indented within
+mappings. The comments, order of the keys, styles of the
+values and blank lines between the entries are kept.
+
+$ helm unittest fmt my-chart
+
+Use --check to list the test suite files to format, without
+writing the files.
+`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  RunFmt,
+}
+
+var explainCmd = &cobra.Command{
+	Use:   "explain --suite SUITE [--test TEST] [flags] CHART [...]",
+	Short: "explain how the tests of a suite are rendered",
+	Long: `Render the tests of a suite without running the assertions,
+and print the merged values, the release and capabilities
+options, the templates selected to render, the post-rendered
+manifests and the documents selected by each assertion.
+The suite is selected by its name or the path of its test
+suite file, all tests of the suite are explained when
+--test is not set.
+
+$ helm unittest explain --suite "test deployment" --test "should work" my-chart
+`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  RunExplain,
+}
+
+var fuzzCmd = &cobra.Command{
+	Use:   "fuzz [flags] CHART [...]",
+	Short: "render the charts with random values of the values schema",
+	Long: `Render the charts with random values which meet the
+values.schema.json of the chart, and check the render
+succeeds, the rendered output parses as YAML and every
+document has an apiVersion, kind and metadata.name. The
+tests of the suites with "fuzz: true" are run with the
+generated values as well, the values of the tests override
+the generated values. The values of failed iterations are
+minimized and written to the failures path.
+
+The same seed generates the same values:
+
+$ helm unittest fuzz --seed 42 --iterations 500 my-chart
+`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  RunFuzz,
+}
+
+var mutateCmd = &cobra.Command{
+	Use:   "mutate [flags] CHART [...]",
+	Short: "measure the tests with mutations of the templates",
+	Long: `Apply mutations to the templates of the charts, like
+flipping if conditions, removing default and quote calls
+and deleting lines of the rendered output, and run the test
+suites of the mutated template for each mutant. A mutant is
+killed when a test fails, the surviving mutants show the
+changes of the templates which are not covered by the tests.
+The tests have to pass without mutations, the snapshots are
+compared but not updated.
+
+$ helm unittest mutate my-chart
+`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  RunMutate,
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config [flags] CHART [...]",
+	Short: "print the effective configuration",
+	Long: `Print the effective configuration of the charts, the options
+of the command line override the project configuration file
+` + "`" + unittest.ProjectConfigFile + "`" + `, found in the chart or the parent directories
+up to the repository root. The test suites override the
+release, capabilities, postRenderer and snapshot settings.
+
+$ helm unittest config --strict my-chart
+`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  RunConfig,
+}
+
 func RunPlugin(cmd *cobra.Command, chartPaths []string) {
+	testRunner = newTestRunner(cmd, chartPaths)
+
+	passed := testRunner.RunV3(chartPaths)
+
+	if !passed {
+		os.Exit(1)
+	}
+}
+
+// RunReview runs the tests and asks to accept, reject or skip each changed and new snapshot.
+func RunReview(cmd *cobra.Command, chartPaths []string) {
+	testRunner = newTestRunner(cmd, chartPaths)
+	// The snapshots are stored by the review, not by updating all snapshots.
+	testRunner.UpdateSnapshot = false
+	testRunner.CI = false
+	testRunner.Reviewer = unittest.NewPromptReviewer(cmd.InOrStdin(), testRunner.Printer, testConfig.lineDiff)
+
+	passed := testRunner.RunV3(chartPaths)
+
+	if !passed {
+		os.Exit(1)
+	}
+}
+
+// RunConvertSnapshots runs the tests and stores the snapshots in the snapshot layout.
+func RunConvertSnapshots(cmd *cobra.Command, chartPaths []string) {
+	testRunner = newTestRunner(cmd, chartPaths)
+	// Only the existing snapshots are converted, missing snapshots are not stored.
+	testRunner.UpdateSnapshot = false
+	testRunner.CI = true
+	testRunner.ConvertSnapshots = true
+
+	testRunner.RunV3(chartPaths)
+}
+
+// RunScaffold writes the test suites of the templates of the charts.
+func RunScaffold(cmd *cobra.Command, chartPaths []string) {
+	testRunner = newTestRunner(cmd, chartPaths)
+	printer := testRunner.Printer
+
+	passed := true
+	for _, chartPath := range chartPaths {
+		result, err := unittest.ScaffoldV3(chartPath, unittest.ScaffoldOptions{
+			WithSnapshot: scaffoldConfig.withSnapshot,
+			Overwrite:    scaffoldConfig.overwrite,
+		})
+		if err != nil {
+			printer.Println(printer.Danger("Error: %s", err), 0)
+			passed = false
+			continue
+		}
+		for _, file := range result.Written {
+			printer.Println(printer.Success("Written: ")+file, 0)
+		}
+		for _, file := range result.Skipped {
+			printer.Println(printer.Faint("Skipped: %s, the test suite exists", file), 0)
+		}
+	}
+
+	if !passed {
+		os.Exit(1)
+	}
+}
+
+// RunLint checks the test suite files of the charts.
+func RunLint(cmd *cobra.Command, chartPaths []string) {
+	testRunner = newTestRunner(cmd, chartPaths)
+
+	passed := testRunner.LintV3(chartPaths)
+
+	if !passed {
+		os.Exit(1)
+	}
+}
+
+// RunFmt formats the test suite files of the charts, or lists the files to format when checking.
+func RunFmt(cmd *cobra.Command, chartPaths []string) {
+	testRunner = newTestRunner(cmd, chartPaths)
+	printer := testRunner.Printer
+
+	passed := true
+	for _, chartPath := range chartPaths {
+		result, err := unittest.FormatV3(chartPath, unittest.FormatOptions{
+			TestFiles: testRunner.TestFiles,
+			Check:     fmtConfig.check,
+		})
+		if err != nil {
+			printer.Println(printer.Danger("Error: %s", err), 0)
+			passed = false
+		}
+		if result == nil {
+			continue
+		}
+		for _, file := range result.Formatted {
+			if fmtConfig.check {
+				printer.Println(printer.Warning("Not formatted: ")+file, 0)
+				passed = false
+			} else {
+				printer.Println(printer.Success("Formatted: ")+file, 0)
+			}
+		}
+	}
+
+	if !passed {
+		os.Exit(1)
+	}
+}
+
+// RunExplain prints how the tests of the suite are rendered.
+func RunExplain(cmd *cobra.Command, chartPaths []string) {
+	testRunner = newTestRunner(cmd, chartPaths)
+
+	passed := testRunner.ExplainV3(chartPaths, explainConfig.suite, explainConfig.test)
+
+	if !passed {
+		os.Exit(1)
+	}
+}
+
+// RunFuzz renders the charts with generated values and checks the rendered output.
+func RunFuzz(cmd *cobra.Command, chartPaths []string) {
+	testRunner = newTestRunner(cmd, chartPaths)
+
+	passed := testRunner.FuzzV3(chartPaths, unittest.FuzzOptions{
+		Seed:         fuzzConfig.seed,
+		Iterations:   fuzzConfig.iterations,
+		FailuresPath: fuzzConfig.failuresPath,
+	})
+
+	if !passed {
+		os.Exit(1)
+	}
+}
+
+// RunMutate runs the tests of the charts against the mutants of the templates.
+func RunMutate(cmd *cobra.Command, chartPaths []string) {
+	testRunner = newTestRunner(cmd, chartPaths)
+
+	passed := testRunner.MutateV3(chartPaths)
+
+	if !passed {
+		os.Exit(1)
+	}
+}
+
+// RunConfig prints the effective configuration of the charts.
+func RunConfig(cmd *cobra.Command, chartPaths []string) {
+	testRunner = newTestRunner(cmd, chartPaths)
+	printer := testRunner.Printer
+
+	var colored *bool
+	if cmd.Flags().Changed("color") || testConfig.colorConfigured {
+		colored = &testConfig.colored
+	}
+	config := unittest.ProjectConfig{
+		TestFiles:      testRunner.TestFiles,
+		ValuesFiles:    testRunner.ValuesFiles,
+		OutputFile:     testRunner.OutputFile,
+		OutputType:     testConfig.outputType,
+		ChartTestsPath: testRunner.ChartTestsPath,
+		SnapshotLayout: string(testRunner.SnapshotLayout),
+		RenderPath:     testRunner.RenderPath,
+		Color:          colored,
+		Strict:         &testRunner.Strict,
+		Failfast:       &testRunner.Failfast,
+		Deterministic:  &testRunner.Deterministic,
+		UpdateSnapshot: &testRunner.UpdateSnapshot,
+		PruneSnapshots: &testRunner.PruneSnapshots,
+		CI:             &testRunner.CI,
+		LineDiff:       &testRunner.LineDiff,
+		WithSubChart:   &testRunner.WithSubChart,
+		RenderDiff:     &testRunner.RenderDiff,
+		DebugPlugin:    &testConfig.debugLogging,
+		SuiteDefaults:  testRunner.SuiteDefaults,
+	}
+	content := new(bytes.Buffer)
+	yamlEncoder := common.YamlNewEncoder(content)
+	yamlEncoder.SetIndent(common.YAMLINDENTION)
+	if err := yamlEncoder.Encode(config); err != nil {
+		printer.Println(printer.Danger("Error: %s", err), 0)
+		os.Exit(1)
+	}
+
+	if projectConfigPath == "" {
+		printer.Println(printer.Faint("# No %s found, the options of the command line", unittest.ProjectConfigFile), 0)
+	} else {
+		printer.Println(printer.Faint("# %s with the options of the command line", projectConfigPath), 0)
+	}
+	printer.Println(content.String(), 0)
+}
+
+// loadProjectConfig reads the project configuration file of the charts, and uses the options of the file for
+// the options which are not set in the command line. The charts have to use the same project configuration file.
+func loadProjectConfig(cmd *cobra.Command, chartPaths []string) (unittest.SuiteDefaults, error) {
+	projectConfigPath = ""
+	for idx, chartPath := range chartPaths {
+		configPath, err := unittest.FindProjectConfig(chartPath)
+		if err != nil {
+			return unittest.SuiteDefaults{}, err
+		}
+		if idx > 0 && configPath != projectConfigPath {
+			return unittest.SuiteDefaults{}, fmt.Errorf(
+				"the charts %s and %s use a different %s, run the charts separately",
+				chartPaths[0], chartPath, unittest.ProjectConfigFile,
+			)
+		}
+		projectConfigPath = configPath
+	}
+	if projectConfigPath == "" {
+		return unittest.SuiteDefaults{}, nil
+	}
+
+	config, err := unittest.LoadProjectConfig(projectConfigPath)
+	if err != nil {
+		return unittest.SuiteDefaults{}, err
+	}
+
+	flags := cmd.Flags()
+	useStrings := func(flag string, option *[]string, value []string) {
+		if !flags.Changed(flag) && len(value) > 0 {
+			*option = value
+		}
+	}
+	useString := func(flag string, option *string, value string) {
+		if !flags.Changed(flag) && value != "" {
+			*option = value
+		}
+	}
+	useBool := func(flag string, option *bool, value *bool) {
+		if !flags.Changed(flag) && value != nil {
+			*option = *value
+		}
+	}
+	useStrings("file", &testConfig.testFiles, config.TestFiles)
+	useStrings("values", &testConfig.valuesFiles, config.ValuesFiles)
+	useString("output-file", &testConfig.outputFile, config.OutputFile)
+	useString("output-type", &testConfig.outputType, config.OutputType)
+	useString("chart-tests-path", &testConfig.chartTestsPath, config.ChartTestsPath)
+	useString("snapshot-layout", &testConfig.snapshotLayout, config.SnapshotLayout)
+	useString("render-path", &testConfig.renderPath, config.RenderPath)
+	useBool("color", &testConfig.colored, config.Color)
+	useBool("strict", &testConfig.useStrict, config.Strict)
+	useBool("failfast", &testConfig.useFailfast, config.Failfast)
+	useBool("deterministic", &testConfig.deterministic, config.Deterministic)
+	useBool("update-snapshot", &testConfig.updateSnapshot, config.UpdateSnapshot)
+	useBool("prune-snapshots", &testConfig.pruneSnapshots, config.PruneSnapshots)
+	useBool("ci", &testConfig.ci, config.CI)
+	useBool("line-diff", &testConfig.lineDiff, config.LineDiff)
+	useBool("with-subchart", &testConfig.withSubChart, config.WithSubChart)
+	useBool("render-diff", &testConfig.renderDiff, config.RenderDiff)
+	useBool("debugPlugin", &testConfig.debugLogging, config.DebugPlugin)
+	testConfig.colorConfigured = config.Color != nil
+	return config.SuiteDefaults, nil
+}
+
+// newTestRunner returns the TestRunner of the options setup by user in command line and the project configuration
+func newTestRunner(cmd *cobra.Command, chartPaths []string) unittest.TestRunner {
+	suiteDefaults, err := loadProjectConfig(cmd, chartPaths)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
 	var colored *bool
-	if cmd.PersistentFlags().Changed("color") {
+	if cmd.Flags().Changed("color") || testConfig.colorConfigured {
 		colored = &testConfig.colored
 	}
 
-	renderPath := ""
-	if testConfig.debugLogging {
+	// The rendered output is written to .debug by default, when it is needed for debugging or the render diff.
+	renderPath := testConfig.renderPath
+	if renderPath == "" && (testConfig.debugLogging || testConfig.renderDiff) {
 		renderPath = ".debug"
+	}
+	if testConfig.debugLogging {
 		log.SetLevel(log.DebugLevel)
 	}
 
@@ -90,10 +551,13 @@ func RunPlugin(cmd *cobra.Command, chartPaths []string) {
 
 	formatter := formatter.NewFormatter(testConfig.outputFile, testConfig.outputType)
 	printer := printer.NewPrinter(os.Stdout, colored)
-	testRunner = unittest.TestRunner{
+	runner := unittest.TestRunner{
 		Printer:        printer,
 		Formatter:      formatter,
 		UpdateSnapshot: testConfig.updateSnapshot,
+		PruneSnapshots: testConfig.pruneSnapshots,
+		CI:             testConfig.ci,
+		LineDiff:       testConfig.lineDiff,
 		WithSubChart:   testConfig.withSubChart,
 		Strict:         testConfig.useStrict,
 		Failfast:       testConfig.useFailfast,
@@ -102,6 +566,10 @@ func RunPlugin(cmd *cobra.Command, chartPaths []string) {
 		OutputFile:     testConfig.outputFile,
 		ChartTestsPath: testConfig.chartTestsPath,
 		RenderPath:     renderPath,
+		RenderDiff:     testConfig.renderDiff,
+		Deterministic:  testConfig.deterministic,
+		SnapshotLayout: snapshot.Layout(testConfig.snapshotLayout),
+		SuiteDefaults:  suiteDefaults,
 	}
 
 	log.SetFormatter(&log.TextFormatter{
@@ -109,11 +577,7 @@ func RunPlugin(cmd *cobra.Command, chartPaths []string) {
 		FullTimestamp: true,
 	})
 
-	passed := testRunner.RunV3(chartPaths)
-
-	if !passed {
-		os.Exit(1)
-	}
+	return runner
 }
 
 // main to execute execute unittest command
@@ -126,6 +590,19 @@ func main() {
 
 func init() {
 	InitPluginFlags(cmd)
+	cmd.AddCommand(reviewCmd)
+	cmd.AddCommand(convertSnapshotsCmd)
+	InitScaffoldFlags(scaffoldCmd)
+	cmd.AddCommand(scaffoldCmd)
+	cmd.AddCommand(lintCmd)
+	InitFmtFlags(fmtCmd)
+	cmd.AddCommand(fmtCmd)
+	InitExplainFlags(explainCmd)
+	cmd.AddCommand(explainCmd)
+	InitFuzzFlags(fuzzCmd)
+	cmd.AddCommand(fuzzCmd)
+	cmd.AddCommand(mutateCmd)
+	cmd.AddCommand(configCmd)
 }
 
 func InitPluginFlags(cmd *cobra.Command) {
@@ -154,6 +631,26 @@ func InitPluginFlags(cmd *cobra.Command) {
 		"update the snapshot cached if needed, make sure you review the change before update",
 	)
 
+	cmd.PersistentFlags().BoolVar(
+		&testConfig.pruneSnapshots, "prune-snapshots", false,
+		"remove the snapshots and snapshot files of tests which no longer exist",
+	)
+
+	cmd.PersistentFlags().BoolVar(
+		&testConfig.ci, "ci", false,
+		"fail the tests with missing snapshots instead of writing them, for continuous integration",
+	)
+
+	cmd.PersistentFlags().BoolVar(
+		&testConfig.lineDiff, "line-diff", false,
+		"show the changed lines of failed snapshots, instead of the changed paths",
+	)
+
+	cmd.PersistentFlags().StringVar(
+		&testConfig.snapshotLayout, "snapshot-layout", string(snapshot.FileLayout),
+		"layout of the snapshot files, a file per test suite (file) or a file per test and document (document)",
+	)
+
 	cmd.PersistentFlags().BoolVarP(
 		&testConfig.withSubChart, "with-subchart", "s", true,
 		"include tests of the subcharts within `charts` folder",
@@ -174,17 +671,81 @@ func InitPluginFlags(cmd *cobra.Command) {
 		"chart-tests-path the folder location relative to the chart where a helm chart to render test suites is located",
 	)
 
+	cmd.PersistentFlags().StringVar(
+		&testConfig.renderPath, "render-path", "",
+		"render-path the folder where the rendered output of each test is written to <render-path>/<chart>/<suite file>/<suite>/<test>, defaults .debug with --debugPlugin",
+	)
+
+	cmd.PersistentFlags().BoolVar(
+		&testConfig.renderDiff, "render-diff", false,
+		"compare the rendered output with the output of the previous run in the render path, and print the changed files",
+	)
+
 	cmd.PersistentFlags().BoolVarP(
 		&testConfig.useFailfast, "failfast", "q", false,
 		"actually directly quit testing, when a test is failed",
 	)
 
+	cmd.PersistentFlags().BoolVar(
+		&testConfig.deterministic, "deterministic", false,
+		"stub the non-deterministic template functions (now, randAlphaNum, uuidv4, genCA, ...) in suites without functions",
+	)
+
 	cmd.PersistentFlags().BoolVarP(
 		&testConfig.debugLogging, "debugPlugin", "d", false,
 		"enable verbose output",
 	)
 }
 
+func InitScaffoldFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&scaffoldConfig.withSnapshot, "with-snapshot", false,
+		"add a matchSnapshot assertion to the scaffolded tests",
+	)
+
+	cmd.Flags().BoolVar(
+		&scaffoldConfig.overwrite, "overwrite", false,
+		"overwrite the existing test suites of the templates",
+	)
+}
+
+func InitFmtFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&fmtConfig.check, "check", false,
+		"list the test suite files to format without writing the files, fails when a file is not formatted",
+	)
+}
+
+func InitExplainFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&explainConfig.suite, "suite", "",
+		"the name of the suite or the path of the test suite file to explain",
+	)
+	_ = cmd.MarkFlagRequired("suite")
+
+	cmd.Flags().StringVar(
+		&explainConfig.test, "test", "",
+		"the name of the test to explain, defaults to all tests of the suite",
+	)
+}
+
+func InitFuzzFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64Var(
+		&fuzzConfig.seed, "seed", 0,
+		"the seed of the generated values, the same seed generates the same values",
+	)
+
+	cmd.Flags().IntVar(
+		&fuzzConfig.iterations, "iterations", 100,
+		"the number of generated values to render per chart",
+	)
+
+	cmd.Flags().StringVar(
+		&fuzzConfig.failuresPath, "failures-path", ".fuzz",
+		"the folder where the minimized values of the failed iterations are written to <chart>-<seed>-<iteration>.yaml",
+	)
+}
+
 func GetTestRunner() unittest.TestRunner {
 	return testRunner
 }
@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	. "github.com/helm-unittest/helm-unittest/cmd/helm-unittest"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
@@ -337,3 +339,320 @@ func TestValidateUnittestChartTestsPathFlag(t *testing.T) {
 func typeofObject(variable interface{}) string {
 	return fmt.Sprintf("%T", variable)
 }
+
+func TestValidateUnittestDeterministicFlags(t *testing.T) {
+	a := assert.New(t)
+
+	deterministicFlags := map[string]bool{
+		"":                      false,
+		"--deterministic":       true,
+		"--deterministic=true":  true,
+		"--deterministic=false": false,
+	}
+
+	for deterministicFlag, deterministicFlagValue := range deterministicFlags {
+		cmd := setupTestCmd()
+		if len(deterministicFlag) > 0 {
+			cmd.SetArgs([]string{deterministicFlag})
+		}
+
+		err := cmd.Execute()
+		runner := GetTestRunner()
+
+		a.Nil(err)
+		a.Equal(deterministicFlagValue, runner.Deterministic)
+	}
+}
+
+func TestValidateUnittestPruneSnapshotsFlags(t *testing.T) {
+	a := assert.New(t)
+
+	pruneSnapshotsFlags := map[string]bool{
+		"":                        false,
+		"--prune-snapshots":       true,
+		"--prune-snapshots=true":  true,
+		"--prune-snapshots=false": false,
+	}
+
+	for pruneSnapshotsFlag, pruneSnapshotsFlagValue := range pruneSnapshotsFlags {
+		cmd := setupTestCmd()
+		if len(pruneSnapshotsFlag) > 0 {
+			cmd.SetArgs([]string{pruneSnapshotsFlag})
+		}
+
+		err := cmd.Execute()
+		runner := GetTestRunner()
+
+		a.Nil(err)
+		a.Equal(pruneSnapshotsFlagValue, runner.PruneSnapshots)
+	}
+}
+
+func TestValidateUnittestCIFlags(t *testing.T) {
+	a := assert.New(t)
+
+	cIFlags := map[string]bool{
+		"":           false,
+		"--ci":       true,
+		"--ci=true":  true,
+		"--ci=false": false,
+	}
+
+	for cIFlag, cIFlagValue := range cIFlags {
+		cmd := setupTestCmd()
+		if len(cIFlag) > 0 {
+			cmd.SetArgs([]string{cIFlag})
+		}
+
+		err := cmd.Execute()
+		runner := GetTestRunner()
+
+		a.Nil(err)
+		a.Equal(cIFlagValue, runner.CI)
+	}
+}
+
+func TestValidateUnittestLineDiffFlags(t *testing.T) {
+	a := assert.New(t)
+
+	lineDiffFlags := map[string]bool{
+		"":                  false,
+		"--line-diff":       true,
+		"--line-diff=true":  true,
+		"--line-diff=false": false,
+	}
+
+	for lineDiffFlag, lineDiffFlagValue := range lineDiffFlags {
+		cmd := setupTestCmd()
+		if len(lineDiffFlag) > 0 {
+			cmd.SetArgs([]string{lineDiffFlag})
+		}
+
+		err := cmd.Execute()
+		runner := GetTestRunner()
+
+		a.Nil(err)
+		a.Equal(lineDiffFlagValue, runner.LineDiff)
+	}
+}
+
+func TestValidateUnittestReviewCommand(t *testing.T) {
+	a := assert.New(t)
+
+	for _, args := range [][]string{{"review"}, {"review", "-u", "--ci"}} {
+		cmd := setupTestCmd()
+		cmd.AddCommand(&cobra.Command{
+			Use: "review",
+			Run: RunReview,
+		})
+		cmd.SetArgs(args)
+
+		err := cmd.Execute()
+		runner := GetTestRunner()
+
+		a.Nil(err)
+		a.NotNil(runner.Reviewer)
+		a.False(runner.UpdateSnapshot)
+		a.False(runner.CI)
+	}
+}
+
+func TestValidateUnittestSnapshotLayoutFlags(t *testing.T) {
+	a := assert.New(t)
+
+	snapshotLayoutFlags := map[string]snapshot.Layout{
+		"":                           snapshot.FileLayout,
+		"--snapshot-layout=document": snapshot.DocumentLayout,
+		"--snapshot-layout=file":     snapshot.FileLayout,
+	}
+
+	for snapshotLayoutFlag, snapshotLayoutFlagValue := range snapshotLayoutFlags {
+		cmd := setupTestCmd()
+		if len(snapshotLayoutFlag) > 0 {
+			cmd.SetArgs([]string{snapshotLayoutFlag})
+		}
+
+		err := cmd.Execute()
+		runner := GetTestRunner()
+
+		a.Nil(err)
+		a.Equal(snapshotLayoutFlagValue, runner.SnapshotLayout)
+	}
+}
+
+func TestValidateUnittestConvertSnapshotsCommand(t *testing.T) {
+	a := assert.New(t)
+
+	cmd := setupTestCmd()
+	cmd.AddCommand(&cobra.Command{
+		Use: "convert-snapshots",
+		Run: RunConvertSnapshots,
+	})
+	cmd.SetArgs([]string{"convert-snapshots", "--snapshot-layout=document", "-u"})
+
+	err := cmd.Execute()
+	runner := GetTestRunner()
+
+	a.Nil(err)
+	a.True(runner.ConvertSnapshots)
+	a.Equal(snapshot.DocumentLayout, runner.SnapshotLayout)
+	a.False(runner.UpdateSnapshot)
+	a.True(runner.CI)
+}
+
+func TestValidateUnittestScaffoldCommand(t *testing.T) {
+	a := assert.New(t)
+	chartPath := filepath.Join(t.TempDir(), "basic")
+	a.NoError(os.CopyFS(chartPath, os.DirFS("../../test/data/v3/basic")))
+	a.NoError(os.RemoveAll(filepath.Join(chartPath, "tests")))
+
+	cmd := setupTestCmd()
+	scaffoldCmd := &cobra.Command{
+		Use:  "scaffold",
+		Args: cobra.MinimumNArgs(1),
+		Run:  RunScaffold,
+	}
+	InitScaffoldFlags(scaffoldCmd)
+	cmd.AddCommand(scaffoldCmd)
+	cmd.SetArgs([]string{"scaffold", "--with-snapshot", chartPath})
+
+	err := cmd.Execute()
+
+	a.Nil(err)
+	content, err := os.ReadFile(filepath.Join(chartPath, "tests", "service_test.yaml"))
+	a.NoError(err)
+	a.Contains(string(content), "      - matchSnapshot: {}\n")
+}
+
+func TestValidateUnittestLintCommand(t *testing.T) {
+	a := assert.New(t)
+
+	cmd := setupTestCmd()
+	cmd.AddCommand(&cobra.Command{
+		Use:  "lint",
+		Args: cobra.MinimumNArgs(1),
+		Run:  RunLint,
+	})
+	cmd.SetArgs([]string{"lint", "-f", "tests/*_test.yaml", "../../test/data/v3/basic"})
+
+	err := cmd.Execute()
+	runner := GetTestRunner()
+
+	a.Nil(err)
+	a.Equal([]string{"tests/*_test.yaml"}, runner.TestFiles)
+}
+
+func TestValidateUnittestExplainCommand(t *testing.T) {
+	a := assert.New(t)
+
+	cmd := setupTestCmd()
+	explainCmd := &cobra.Command{
+		Use:  "explain",
+		Args: cobra.MinimumNArgs(1),
+		Run:  RunExplain,
+	}
+	InitExplainFlags(explainCmd)
+	cmd.AddCommand(explainCmd)
+	cmd.SetArgs([]string{"explain", "--suite", "tests/deployment_test.yaml", "--test", "should pass all kinds of assertion", "../../test/data/v3/basic"})
+
+	err := cmd.Execute()
+	runner := GetTestRunner()
+
+	a.Nil(err)
+	a.Equal([]string{filepath.Join("tests", "*_test.yaml")}, runner.TestFiles)
+}
+
+func TestValidateUnittestFmtCommand(t *testing.T) {
+	a := assert.New(t)
+	chartPath := t.TempDir()
+	suiteFile := filepath.Join(chartPath, "tests", "format_test.yaml")
+	a.NoError(os.MkdirAll(filepath.Dir(suiteFile), 0755))
+	a.NoError(os.WriteFile(suiteFile, []byte("suite: format\ntests:\n- it: should format\n  asserts:\n  - hasDocuments:\n      count: 1\n"), 0644))
+
+	cmd := setupTestCmd()
+	fmtCmd := &cobra.Command{
+		Use:  "fmt",
+		Args: cobra.MinimumNArgs(1),
+		Run:  RunFmt,
+	}
+	InitFmtFlags(fmtCmd)
+	cmd.AddCommand(fmtCmd)
+	cmd.SetArgs([]string{"fmt", "-f", "tests/*_test.yaml", chartPath})
+
+	err := cmd.Execute()
+
+	a.Nil(err)
+	content, err := os.ReadFile(suiteFile)
+	a.NoError(err)
+	a.Equal("suite: format\ntests:\n  - it: should format\n    asserts:\n      - hasDocuments:\n          count: 1\n", string(content))
+}
+
+func TestValidateUnittestProjectConfig(t *testing.T) {
+	a := assert.New(t)
+	chartPath := t.TempDir()
+	a.NoError(os.WriteFile(filepath.Join(chartPath, unittest.ProjectConfigFile), []byte(`testFiles:
+  - tests/**/*_test.yaml
+strict: true
+ci: true
+release:
+  name: my-release
+`), 0644))
+
+	cmd := setupTestCmd()
+	cmd.AddCommand(&cobra.Command{
+		Use:  "config",
+		Args: cobra.MinimumNArgs(1),
+		Run:  RunConfig,
+	})
+	cmd.SetArgs([]string{"config", "--ci=false", chartPath})
+
+	err := cmd.Execute()
+	runner := GetTestRunner()
+
+	a.Nil(err)
+	a.Equal([]string{"tests/**/*_test.yaml"}, runner.TestFiles)
+	a.True(runner.Strict)
+	// The options of the command line override the project configuration file.
+	a.False(runner.CI)
+	a.Equal("my-release", runner.SuiteDefaults.Release.Name)
+}
+
+func TestValidateUnittestFuzzCommand(t *testing.T) {
+	a := assert.New(t)
+	failuresPath := filepath.Join(t.TempDir(), "fuzz")
+
+	cmd := setupTestCmd()
+	fuzzCmd := &cobra.Command{
+		Use:  "fuzz",
+		Args: cobra.MinimumNArgs(1),
+		Run:  RunFuzz,
+	}
+	InitFuzzFlags(fuzzCmd)
+	cmd.AddCommand(fuzzCmd)
+	cmd.SetArgs([]string{"fuzz", "--seed", "42", "--iterations", "5", "--failures-path", failuresPath, "../../test/data/v3/with-schema"})
+
+	err := cmd.Execute()
+
+	a.Nil(err)
+	a.NoDirExists(failuresPath)
+}
+
+func TestValidateUnittestMutateCommand(t *testing.T) {
+	a := assert.New(t)
+	chartPath := t.TempDir()
+	a.NoError(os.WriteFile(filepath.Join(chartPath, "Chart.yaml"), []byte("apiVersion: v2\nname: mutate\nversion: 0.1.0\n"), 0644))
+
+	cmd := setupTestCmd()
+	cmd.AddCommand(&cobra.Command{
+		Use:  "mutate",
+		Args: cobra.MinimumNArgs(1),
+		Run:  RunMutate,
+	})
+	cmd.SetArgs([]string{"mutate", "-f", "tests/*_test.yaml", chartPath})
+
+	err := cmd.Execute()
+	runner := GetTestRunner()
+
+	a.Nil(err)
+	a.Equal([]string{"tests/*_test.yaml"}, runner.TestFiles)
+}
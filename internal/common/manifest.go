@@ -0,0 +1,66 @@
+package common
+
+// NestedValue returns the value of the nested fields of the object, false when a field is not found or nil.
+func NestedValue(object map[string]interface{}, fields ...string) (interface{}, bool) {
+	var current interface{} = object
+	for _, field := range fields {
+		currentMap, ok := toMap(current)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = currentMap[field]; !ok {
+			return nil, false
+		}
+	}
+	return current, current != nil
+}
+
+// NestedMap returns the mapping of the nested fields of the object, false when not found or not a mapping.
+func NestedMap(object map[string]interface{}, fields ...string) (map[string]interface{}, bool) {
+	value, ok := NestedValue(object, fields...)
+	if !ok {
+		return nil, false
+	}
+	return toMap(value)
+}
+
+// NestedString returns the string of the nested fields of the object, false when not found or not a string.
+func NestedString(object map[string]interface{}, fields ...string) (string, bool) {
+	value, ok := NestedValue(object, fields...)
+	if !ok {
+		return "", false
+	}
+	str, ok := value.(string)
+	return str, ok
+}
+
+// NestedSlice returns the mappings of the sequence of the nested fields of the object, false when not found or
+// not a sequence. Items which are not a mapping are nil.
+func NestedSlice(object map[string]interface{}, fields ...string) ([]map[string]interface{}, bool) {
+	value, ok := NestedValue(object, fields...)
+	if !ok {
+		return nil, false
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	result := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		itemMap, _ := toMap(item)
+		result = append(result, itemMap)
+	}
+	return result, true
+}
+
+// toMap returns the mapping of a decoded value, which is either a plain map or a K8sManifest.
+func toMap(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case K8sManifest:
+		return v, true
+	default:
+		return nil, false
+	}
+}
@@ -2,8 +2,10 @@ package common
 
 import (
 	"bytes"
+	"strconv"
 	"strings"
 	"testing"
+	"unicode"
 
 	"io"
 
@@ -103,3 +105,30 @@ func SplitBefore(s, sep string) []string {
 	}
 	return out
 }
+
+// FileNameOf returns name with the characters which are not letters, digits, dots or underscores
+// replaced by a dash, like `Deployment-my-app` for `Deployment/my-app`.
+func FileNameOf(name string) string {
+	var builder strings.Builder
+	dash := false
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '_' {
+			builder.WriteRune(r)
+			dash = false
+		} else if !dash {
+			builder.WriteRune('-')
+			dash = true
+		}
+	}
+	return strings.Trim(builder.String(), "-.")
+}
+
+// UniqueName returns name, numbered when already used.
+func UniqueName(name string, used map[string]bool) string {
+	unique := name
+	for count := 2; used[unique]; count++ {
+		unique = name + "-" + strconv.Itoa(count)
+	}
+	used[unique] = true
+	return unique
+}
@@ -26,7 +26,11 @@ type Assertion struct {
 	requireRenderSuccess bool
 	antonym              bool
 	defaultTemplates     []string
-	config               AssertionConfig
+	// validatesValues the assertion validates the values, instead of the rendered documents
+	validatesValues bool
+	// validatesRelease the assertion validates all rendered documents once, instead of the documents per template
+	validatesRelease bool
+	config           AssertionConfig
 }
 
 func (a *Assertion) WithConfig(config AssertionConfig) {
@@ -47,10 +51,16 @@ func (a *Assertion) Assert(
 	result.AssertType = a.AssertType
 	result.Not = a.Not
 
-	var templates = a.computeTemplatesWithPostRender()
+	if a.validatesValues {
+		return a.evaluateValues(result)
+	}
+
+	if a.validatesRelease {
+		return a.evaluateRelease(result)
+	}
 
 	// TODO: This could be optimised and computed once for the test suite
-	selectedDocsByTemplate, indexError := a.selectDocumentsForAssertion(templates)
+	selectedDocsByTemplate, indexError := a.selectDocuments()
 	selectedTemplates := a.getKeys(selectedDocsByTemplate)
 
 	// Sort templates to ensure a consistent output
@@ -107,7 +117,7 @@ func (a *Assertion) evaluateEmptyTemplates(
 		failInfo = append(failInfo, invalidRender)
 	} else {
 		var emptyTemplate []common.K8sManifest
-		_, validatePassed, failInfo = a.validateTemplate(emptyTemplate, emptyTemplate)
+		_, validatePassed, failInfo = a.validateTemplate("", emptyTemplate, emptyTemplate)
 	}
 
 	result.Passed = validatePassed
@@ -115,6 +125,39 @@ func (a *Assertion) evaluateEmptyTemplates(
 	return result
 }
 
+// evaluateValues evaluates the assertion of the values, independent of the rendered templates
+// It returns the assertion result with the validation status and failure information
+func (a *Assertion) evaluateValues(result *results.AssertionResult) *results.AssertionResult {
+	result.Passed, result.FailInfo = a.validator.Validate(&validators.ValidateContext{
+		Negative:      a.Not != a.antonym,
+		RenderError:   a.configOrDefault().renderError,
+		TemplateError: a.configOrDefault().templateError,
+		FailFast:      a.configOrDefault().failFast,
+	})
+	return result
+}
+
+// evaluateRelease evaluates the assertion once for the documents of all rendered templates, compared with the
+// documents of all templates of the previous render
+// It returns the assertion result with the validation status and failure information
+func (a *Assertion) evaluateRelease(result *results.AssertionResult) *results.AssertionResult {
+	if a.requireRenderSuccess != a.configOrDefault().renderSucceed {
+		result.Passed = false
+		result.FailInfo = []string{"Error: rendered manifest is empty"}
+		return result
+	}
+
+	allDocs := a.allDocuments()
+	result.Passed, result.FailInfo = a.validator.Validate(&validators.ValidateContext{
+		Docs:            allDocs,
+		AllDocs:         allDocs,
+		AllPreviousDocs: a.flattenDocuments(a.configOrDefault().previousTemplatesResult),
+		Negative:        a.Not != a.antonym,
+		FailFast:        a.configOrDefault().failFast,
+	})
+	return result
+}
+
 // evaluateTemplates evaluates the assertion for each selected template
 // It processes the templates and validates them using the configured validator
 // It returns the assertion result with the validation status and failure information
@@ -159,7 +202,7 @@ func (a *Assertion) processTemplate(template string, selectedDocs []common.K8sMa
 		return true, false, a.handleRenderError(rendered)
 	}
 
-	return a.validateTemplate(rendered, selectedDocs)
+	return a.validateTemplate(template, rendered, selectedDocs)
 }
 
 // handleRenderError handles the error when the rendered manifest is empty
@@ -174,22 +217,67 @@ func (a *Assertion) handleRenderError(rendered []common.K8sManifest) []string {
 // validateTemplate validates the rendered template using the configured validator
 // It returns a boolean indicating if the template needs to be added in the failure information,
 // a boolean indicating if the template is valid and a slice of failure information
-func (a *Assertion) validateTemplate(rendered []common.K8sManifest, selectedDocs []common.K8sManifest) (bool, bool, []string) {
+func (a *Assertion) validateTemplate(template string, rendered []common.K8sManifest, selectedDocs []common.K8sManifest) (bool, bool, []string) {
 	var validatePassed bool
 	var singleFailInfo []string
 
 	validatePassed, singleFailInfo = a.validator.Validate(&validators.ValidateContext{
-		Docs:             rendered,
-		SelectedDocs:     &selectedDocs,
-		Negative:         a.Not != a.antonym,
-		SnapshotComparer: a.configOrDefault().snapshotComparer,
-		RenderError:      a.configOrDefault().renderError,
-		FailFast:         a.configOrDefault().failFast,
+		Docs:                   rendered,
+		SelectedDocs:           &selectedDocs,
+		DocumentIndices:        documentIndices(rendered, selectedDocs),
+		AllDocs:                a.allDocuments(),
+		AllPreviousDocs:        a.flattenDocuments(a.configOrDefault().previousTemplatesResult),
+		Template:               template,
+		Negative:               a.Not != a.antonym,
+		SnapshotComparer:       a.configOrDefault().snapshotComparer,
+		SnapshotRedaction:      a.configOrDefault().snapshotRedaction,
+		InlineSnapshotComparer: a.configOrDefault().inlineSnapshotComparer,
+		RenderError:            a.configOrDefault().renderError,
+		TemplateError:          a.configOrDefault().templateError,
+		FailFast:               a.configOrDefault().failFast,
 	})
 
 	return true, validatePassed, singleFailInfo
 }
 
+// allDocuments returns the documents of all templates rendered by the test job, also the templates which are not
+// selected by the templates of the suite and test when rendered.
+func (a *Assertion) allDocuments() []common.K8sManifest {
+	if a.configOrDefault().allTemplatesResult != nil {
+		return a.flattenDocuments(a.configOrDefault().allTemplatesResult)
+	}
+	return a.flattenDocuments(a.configOrDefault().templatesResult)
+}
+
+// flattenDocuments returns all documents of the rendered templates, in a consistent template order.
+func (a *Assertion) flattenDocuments(templatesResult map[string][]common.K8sManifest) []common.K8sManifest {
+	if templatesResult == nil {
+		return nil
+	}
+	templates := a.getKeys(templatesResult)
+	sort.Strings(templates)
+
+	allDocs := make([]common.K8sManifest, 0)
+	for _, template := range templates {
+		allDocs = append(allDocs, templatesResult[template]...)
+	}
+	return allDocs
+}
+
+// documentIndices returns the indices of the selected documents in the rendered documents of a template.
+func documentIndices(rendered, selected []common.K8sManifest) []int {
+	indices := make([]int, 0, len(selected))
+	for _, selectedDoc := range selected {
+		for idx, doc := range rendered {
+			if reflect.ValueOf(doc).Pointer() == reflect.ValueOf(selectedDoc).Pointer() {
+				indices = append(indices, idx)
+				break
+			}
+		}
+	}
+	return indices
+}
+
 func (a *Assertion) getDocumentsByDefaultTemplates(templatesResult map[string][]common.K8sManifest) map[string][]common.K8sManifest {
 	documentsByDefaultTemplates := map[string][]common.K8sManifest{}
 
@@ -210,6 +298,12 @@ func (a *Assertion) getKeys(docs map[string][]common.K8sManifest) []string {
 	return keys
 }
 
+// selectDocuments returns the documents of the templates selected by the template, documentIndex and
+// documentSelector of the assertion.
+func (a *Assertion) selectDocuments() (map[string][]common.K8sManifest, error) {
+	return a.selectDocumentsForAssertion(a.computeTemplatesWithPostRender())
+}
+
 func (a *Assertion) selectDocumentsForAssertion(docs map[string][]common.K8sManifest) (map[string][]common.K8sManifest, error) {
 	if a.DocumentSelector != nil && a.DocumentSelector.Path != "" {
 		return a.DocumentSelector.SelectDocuments(docs)
@@ -330,6 +424,8 @@ func (a *Assertion) constructValidator(assertDef map[string]interface{}) error {
 			a.requireRenderSuccess = correspondDef.expectRenderSuccess
 			a.antonym = correspondDef.antonym
 			a.defaultTemplates = []string{a.Template}
+			a.validatesValues = valuesAssertTypes[assertName]
+			a.validatesRelease = releaseAssertTypes[assertName]
 		}
 	}
 	return nil
@@ -363,40 +459,70 @@ type assertTypeDef struct {
 }
 
 var assertTypeMapping = map[string]assertTypeDef{
-	"matchSnapshot":     {reflect.TypeOf(validators.MatchSnapshotValidator{}), false, true},
-	"matchSnapshotRaw":  {reflect.TypeOf(validators.MatchSnapshotRawValidator{}), false, true},
-	"equal":             {reflect.TypeOf(validators.EqualValidator{}), false, true},
-	"notEqual":          {reflect.TypeOf(validators.EqualValidator{}), true, true},
-	"greaterOrEqual":    {reflect.TypeOf(validators.EqualOrGreaterValidator{}), false, true},
-	"notGreaterOrEqual": {reflect.TypeOf(validators.EqualOrGreaterValidator{}), true, true},
-	"lessOrEqual":       {reflect.TypeOf(validators.EqualOrLessValidator{}), false, true},
-	"notLessOrEqual":    {reflect.TypeOf(validators.EqualOrLessValidator{}), true, true},
-	"equalRaw":          {reflect.TypeOf(validators.EqualRawValidator{}), false, true},
-	"notEqualRaw":       {reflect.TypeOf(validators.EqualRawValidator{}), true, true},
-	"exists":            {reflect.TypeOf(validators.ExistsValidator{}), false, true},
-	"notExists":         {reflect.TypeOf(validators.ExistsValidator{}), true, true},
-	"matchRegex":        {reflect.TypeOf(validators.MatchRegexValidator{}), false, true},
-	"notMatchRegex":     {reflect.TypeOf(validators.MatchRegexValidator{}), true, true},
-	"matchRegexRaw":     {reflect.TypeOf(validators.MatchRegexRawValidator{}), false, true},
-	"notMatchRegexRaw":  {reflect.TypeOf(validators.MatchRegexRawValidator{}), true, true},
-	"contains":          {reflect.TypeOf(validators.ContainsValidator{}), false, true},
-	"notContains":       {reflect.TypeOf(validators.ContainsValidator{}), true, true},
-	"isKind":            {reflect.TypeOf(validators.IsKindValidator{}), false, true},
-	"isAPIVersion":      {reflect.TypeOf(validators.IsAPIVersionValidator{}), false, true},
-	"hasDocuments":      {reflect.TypeOf(validators.HasDocumentsValidator{}), false, true},
-	"isSubset":          {reflect.TypeOf(validators.IsSubsetValidator{}), false, true},
-	"isNotSubset":       {reflect.TypeOf(validators.IsSubsetValidator{}), true, true},
-	"isNullOrEmpty":     {reflect.TypeOf(validators.IsNullOrEmptyValidator{}), false, true},
-	"isNotNullOrEmpty":  {reflect.TypeOf(validators.IsNullOrEmptyValidator{}), true, true},
-	"failedTemplate":    {reflect.TypeOf(validators.FailedTemplateValidator{}), false, false},
-	"notFailedTemplate": {reflect.TypeOf(validators.FailedTemplateValidator{}), true, true},
-	"containsDocument":  {reflect.TypeOf(validators.ContainsDocumentValidator{}), false, true},
-	"lengthEqual":       {reflect.TypeOf(validators.LengthEqualDocumentsValidator{}), false, true},
-	"notLengthEqual":    {reflect.TypeOf(validators.LengthEqualDocumentsValidator{}), true, true},
-	"isNull":            {reflect.TypeOf(validators.ExistsValidator{}), true, true},
-	"isNotNull":         {reflect.TypeOf(validators.ExistsValidator{}), false, true},
-	"isEmpty":           {reflect.TypeOf(validators.IsNullOrEmptyValidator{}), false, true},
-	"isNotEmpty":        {reflect.TypeOf(validators.IsNullOrEmptyValidator{}), true, true},
-	"isType":            {reflect.TypeOf(validators.IsTypeValidator{}), false, true},
-	"isNotType":         {reflect.TypeOf(validators.IsTypeValidator{}), true, true},
+	"matchSnapshot":               {reflect.TypeOf(validators.MatchSnapshotValidator{}), false, true},
+	"matchSnapshotRaw":            {reflect.TypeOf(validators.MatchSnapshotRawValidator{}), false, true},
+	"matchInlineSnapshot":         {reflect.TypeOf(validators.MatchInlineSnapshotValidator{}), false, true},
+	"equal":                       {reflect.TypeOf(validators.EqualValidator{}), false, true},
+	"notEqual":                    {reflect.TypeOf(validators.EqualValidator{}), true, true},
+	"greaterOrEqual":              {reflect.TypeOf(validators.EqualOrGreaterValidator{}), false, true},
+	"notGreaterOrEqual":           {reflect.TypeOf(validators.EqualOrGreaterValidator{}), true, true},
+	"lessOrEqual":                 {reflect.TypeOf(validators.EqualOrLessValidator{}), false, true},
+	"notLessOrEqual":              {reflect.TypeOf(validators.EqualOrLessValidator{}), true, true},
+	"equalRaw":                    {reflect.TypeOf(validators.EqualRawValidator{}), false, true},
+	"notEqualRaw":                 {reflect.TypeOf(validators.EqualRawValidator{}), true, true},
+	"exists":                      {reflect.TypeOf(validators.ExistsValidator{}), false, true},
+	"notExists":                   {reflect.TypeOf(validators.ExistsValidator{}), true, true},
+	"matchRegex":                  {reflect.TypeOf(validators.MatchRegexValidator{}), false, true},
+	"notMatchRegex":               {reflect.TypeOf(validators.MatchRegexValidator{}), true, true},
+	"matchRegexRaw":               {reflect.TypeOf(validators.MatchRegexRawValidator{}), false, true},
+	"notMatchRegexRaw":            {reflect.TypeOf(validators.MatchRegexRawValidator{}), true, true},
+	"contains":                    {reflect.TypeOf(validators.ContainsValidator{}), false, true},
+	"notContains":                 {reflect.TypeOf(validators.ContainsValidator{}), true, true},
+	"isKind":                      {reflect.TypeOf(validators.IsKindValidator{}), false, true},
+	"isAPIVersion":                {reflect.TypeOf(validators.IsAPIVersionValidator{}), false, true},
+	"hasDocuments":                {reflect.TypeOf(validators.HasDocumentsValidator{}), false, true},
+	"isSubset":                    {reflect.TypeOf(validators.IsSubsetValidator{}), false, true},
+	"isNotSubset":                 {reflect.TypeOf(validators.IsSubsetValidator{}), true, true},
+	"isNullOrEmpty":               {reflect.TypeOf(validators.IsNullOrEmptyValidator{}), false, true},
+	"isNotNullOrEmpty":            {reflect.TypeOf(validators.IsNullOrEmptyValidator{}), true, true},
+	"failedTemplate":              {reflect.TypeOf(validators.FailedTemplateValidator{}), false, false},
+	"notFailedTemplate":           {reflect.TypeOf(validators.FailedTemplateValidator{}), true, true},
+	"containsDocument":            {reflect.TypeOf(validators.ContainsDocumentValidator{}), false, true},
+	"lengthEqual":                 {reflect.TypeOf(validators.LengthEqualDocumentsValidator{}), false, true},
+	"notLengthEqual":              {reflect.TypeOf(validators.LengthEqualDocumentsValidator{}), true, true},
+	"isNull":                      {reflect.TypeOf(validators.ExistsValidator{}), true, true},
+	"isNotNull":                   {reflect.TypeOf(validators.ExistsValidator{}), false, true},
+	"isEmpty":                     {reflect.TypeOf(validators.IsNullOrEmptyValidator{}), false, true},
+	"isNotEmpty":                  {reflect.TypeOf(validators.IsNullOrEmptyValidator{}), true, true},
+	"isType":                      {reflect.TypeOf(validators.IsTypeValidator{}), false, true},
+	"isNotType":                   {reflect.TypeOf(validators.IsTypeValidator{}), true, true},
+	"referencesResolve":           {reflect.TypeOf(validators.ReferencesResolveValidator{}), false, true},
+	"notReferencesResolve":        {reflect.TypeOf(validators.ReferencesResolveValidator{}), true, true},
+	"immutableFieldsUnchanged":    {reflect.TypeOf(validators.ImmutableFieldsUnchangedValidator{}), false, true},
+	"notImmutableFieldsUnchanged": {reflect.TypeOf(validators.ImmutableFieldsUnchangedValidator{}), true, true},
+	"noResourceRemoved":           {reflect.TypeOf(validators.NoResourceRemovedValidator{}), false, true},
+	"notNoResourceRemoved":        {reflect.TypeOf(validators.NoResourceRemovedValidator{}), true, true},
+	"failedSchemaValidation":      {reflect.TypeOf(validators.FailedSchemaValidationValidator{}), false, false},
+	"notFailedSchemaValidation":   {reflect.TypeOf(validators.FailedSchemaValidationValidator{}), true, true},
+}
+
+// valuesAssertTypes the assert types which validate the values, the only assert types of schemaOnly test suites
+var valuesAssertTypes = map[string]bool{
+	"failedSchemaValidation":    true,
+	"notFailedSchemaValidation": true,
+}
+
+// allTemplatesAssertTypes the assert types which validate the documents against the documents of all templates of
+// the chart, which are rendered also when not selected by the templates of the suite and test
+var allTemplatesAssertTypes = map[string]bool{
+	"referencesResolve":    true,
+	"notReferencesResolve": true,
+	"noResourceRemoved":    true,
+	"notNoResourceRemoved": true,
+}
+
+// releaseAssertTypes the assert types which validate the documents of all rendered templates at once
+var releaseAssertTypes = map[string]bool{
+	"noResourceRemoved":    true,
+	"notNoResourceRemoved": true,
 }
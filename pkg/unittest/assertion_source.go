@@ -0,0 +1,54 @@
+package unittest
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/results"
+	"gopkg.in/yaml.v3"
+)
+
+// polishAssertionSources sets the test suite file and the line of the failed assertions. The assertions are
+// located with the yaml nodes of the test suite file, the assertions of rendered test suites have no source.
+func (s *TestSuite) polishAssertionSources(jobResults []*results.TestJobResult) {
+	if s.fromRender {
+		return
+	}
+
+	var document *yaml.Node
+	for _, jobResult := range jobResults {
+		if jobResult == nil || jobResult.Passed {
+			continue
+		}
+		for _, assertionResult := range jobResult.AssertsResult {
+			if assertionResult.Passed {
+				continue
+			}
+			if document == nil {
+				content, err := os.ReadFile(s.definitionFile)
+				if err != nil {
+					return
+				}
+				if document, err = suiteDocument(string(content), s.documentIndex); err != nil {
+					return
+				}
+			}
+			if line := assertionLine(document, jobResult.Index, assertionResult.Index); line > 0 {
+				assertionResult.Source = fmt.Sprintf("%s:%d", s.definitionFile, line)
+			}
+		}
+	}
+}
+
+// assertionLine returns the line of the assertion of the test in the test suite, 0 when not found.
+func assertionLine(document *yaml.Node, test, assertion int) int {
+	_, tests := mappingEntry(document, "tests")
+	if tests == nil || tests.Kind != yaml.SequenceNode || test >= len(tests.Content) {
+		return 0
+	}
+	_, asserts := mappingEntry(tests.Content[test], "asserts")
+	if asserts == nil || asserts.Kind != yaml.SequenceNode || assertion >= len(asserts.Content) {
+		return 0
+	}
+	return asserts.Content[assertion].Line
+}
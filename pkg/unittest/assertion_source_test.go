@@ -0,0 +1,58 @@
+package unittest_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest"
+)
+
+func TestV3RunnerWithAssertionSource(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "basic")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS(testV3BasicChart)))
+	assert.NoError(t, os.RemoveAll(filepath.Join(chartPath, "tests")))
+	assert.NoError(t, os.Mkdir(filepath.Join(chartPath, "tests"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "deployment_test.yaml"), []byte(`suite: first
+templates:
+  - configmap.yaml
+  - deployment.yaml
+tests:
+  - it: should pass
+    template: deployment.yaml
+    asserts:
+      - hasDocuments:
+          count: 2
+---
+suite: second
+templates:
+  - configmap.yaml
+  - deployment.yaml
+tests:
+  - it: should fail on the second document
+    template: deployment.yaml
+    asserts:
+      - isKind:
+          of: Deployment
+        documentIndex: 1
+      - equal:
+          path: kind
+          value: Service
+        documentIndex: 1
+`), 0644))
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/*_test.yaml"},
+	}
+	assert.False(t, runner.RunV3([]string{chartPath}))
+	assert.Contains(t, buffer.String(), "- asserts[1] `equal` fail\n\t\t\tSource:\t")
+	assert.Contains(t, buffer.String(), filepath.Join("tests", "deployment_test.yaml")+":23\n")
+	assert.Contains(t, buffer.String(), "Template:\tbasic/templates/deployment.yaml\n\t\t\tDocumentIndex:\t1\n")
+	assert.NotContains(t, buffer.String(), "asserts[0]")
+}
@@ -81,13 +81,19 @@ func TestAssertionUnmarshalFromYAML(t *testing.T) {
 - lengthEqual:
 - matchSnapshot:
 - matchSnapshotRaw:
+- referencesResolve:
+- notReferencesResolve:
+- immutableFieldsUnchanged:
+- notImmutableFieldsUnchanged:
+- noResourceRemoved:
+- notNoResourceRemoved:
 `
 
 	a := assert.New(t)
-	assertionsAsMap := make([]map[string]interface{}, 33)
+	assertionsAsMap := make([]map[string]interface{}, 41)
 	common.YmlUnmarshalTestHelper(assertionsYAML, &assertionsAsMap, t)
 
-	assertions := make([]Assertion, 33)
+	assertions := make([]Assertion, 41)
 	common.YmlUnmarshalTestHelper(assertionsYAML, &assertions, t)
 
 	for idx, assertion := range assertions {
@@ -212,10 +218,19 @@ func TestReverseAssertionTheSameAsOriginalOneWithNotTrue(t *testing.T) {
 - failedTemplate:
   not: true
 - notFailedTemplate:
+- referencesResolve:
+  not: true
+- notReferencesResolve:
+- immutableFieldsUnchanged:
+  not: true
+- notImmutableFieldsUnchanged:
+- noResourceRemoved:
+  not: true
+- notNoResourceRemoved:
 `
 	a := assert.New(t)
 
-	assertions := make([]Assertion, 28)
+	assertions := make([]Assertion, 34)
 	common.YmlUnmarshalTestHelper(assertionsYAML, &assertions, t)
 
 	for idx := 0; idx < len(assertions); idx += 2 {
@@ -332,6 +347,158 @@ func TestAssertionRawAssertWhenOk(t *testing.T) {
 	validateSucceededTestAssertions(t, assertionsYAML, 5, renderedMap, false)
 }
 
+func TestAssertionReferencesResolveAcrossTemplatesWhenOk(t *testing.T) {
+	deployment := common.TrustedUnmarshalYAML(`
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      volumes:
+        - name: config
+          configMap:
+            name: web-config
+`)
+	configMap := common.TrustedUnmarshalYAML(`
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: web-config
+`)
+	renderedMap := map[string][]common.K8sManifest{
+		"deployment.yaml": {deployment},
+		"configmap.yaml":  {configMap},
+	}
+
+	assertionsYAML := `
+- template: deployment.yaml
+  referencesResolve: {}
+- template: configmap.yaml
+  referencesResolve:
+    kinds:
+      - configMap
+`
+	validateSucceededTestAssertions(t, assertionsYAML, 2, renderedMap, false)
+}
+
+func TestAssertionUpgradeAssertionsWithPreviousRenderWhenOk(t *testing.T) {
+	previous := common.TrustedUnmarshalYAML(`
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: web
+spec:
+  selector:
+    matchLabels:
+      app: web
+`)
+	current := common.TrustedUnmarshalYAML(`
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: web
+spec:
+  replicas: 3
+  selector:
+    matchLabels:
+      app: web
+`)
+
+	assertionsYAML := `
+- template: deployment.yaml
+  immutableFieldsUnchanged: {}
+- template: deployment.yaml
+  noResourceRemoved: {}
+`
+	assertions := make([]Assertion, 2)
+	common.YmlUnmarshalTestHelper(assertionsYAML, &assertions, t)
+
+	a := assert.New(t)
+	for idx, assertion := range assertions {
+		cfg := AssertionConfigBuilder{
+			TemplatesResult:         map[string][]common.K8sManifest{"deployment.yaml": {current}},
+			PreviousTemplatesResult: map[string][]common.K8sManifest{"deployment.yaml": {previous}},
+			SnapshotComparer:        fakeSnapshotComparer(true),
+			RenderSucceed:           true,
+		}
+		assertion.WithConfig(cfg.Build())
+		result := assertion.Assert(&results.AssertionResult{Index: idx})
+		a.True(result.Passed, result.FailInfo)
+	}
+}
+
+func TestAssertionUpgradeAssertionsWithoutPreviousRenderFail(t *testing.T) {
+	manifest := common.TrustedUnmarshalYAML(`
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: web
+`)
+	assertionYAML := `
+template: deployment.yaml
+noResourceRemoved: {}
+`
+	assertion := new(Assertion)
+	common.YmlUnmarshalTestHelper(assertionYAML, &assertion, t)
+
+	cfg := AssertionConfigBuilder{
+		TemplatesResult:  map[string][]common.K8sManifest{"deployment.yaml": {manifest}},
+		SnapshotComparer: fakeSnapshotComparer(true),
+		RenderSucceed:    true,
+	}
+	assertion.WithConfig(cfg.Build())
+	result := assertion.Assert(&results.AssertionResult{Index: 0})
+
+	a := assert.New(t)
+	a.False(result.Passed)
+	a.Equal([]string{"Error:", "\tno previous render found, define 'upgradeFrom' in the test suite or test job"}, result.FailInfo)
+}
+
+func TestAssertionNoResourceRemovedOfTemplateWhichRendersNothing(t *testing.T) {
+	deployment := common.TrustedUnmarshalYAML(`
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: web
+`)
+	networkPolicy := common.TrustedUnmarshalYAML(`
+kind: NetworkPolicy
+apiVersion: networking.k8s.io/v1
+metadata:
+  name: web
+`)
+	assertionYAML := `
+template: deployment.yaml
+noResourceRemoved: {}
+`
+	assertion := new(Assertion)
+	common.YmlUnmarshalTestHelper(assertionYAML, &assertion, t)
+
+	cfg := AssertionConfigBuilder{
+		TemplatesResult: map[string][]common.K8sManifest{"deployment.yaml": {deployment}},
+		PreviousTemplatesResult: map[string][]common.K8sManifest{
+			"deployment.yaml":    {deployment},
+			"networkpolicy.yaml": {networkPolicy},
+		},
+		SnapshotComparer: fakeSnapshotComparer(true),
+		RenderSucceed:    true,
+	}
+	assertion.WithConfig(cfg.Build())
+	result := assertion.Assert(&results.AssertionResult{Index: 0})
+
+	a := assert.New(t)
+	a.False(result.Passed)
+	a.Equal([]string{
+		"Expected to keep resources:",
+		"\tDeployment/web",
+		"\tNetworkPolicy/web",
+		"Actual:",
+		"\tNetworkPolicy/web removed",
+	}, result.FailInfo)
+}
+
 func TestAssertionAssertWhenTemplateNotExisted(t *testing.T) {
 	manifest := common.K8sManifest{}
 	renderedMap := map[string][]common.K8sManifest{
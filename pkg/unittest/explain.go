@@ -0,0 +1,224 @@
+package unittest
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	v3chart "helm.sh/helm/v3/pkg/chart"
+	v3loader "helm.sh/helm/v3/pkg/chart/loader"
+)
+
+// ExplainV3 prints how the tests of a suite are rendered, without running the assertions: the merged values,
+// the release and capabilities options, the templates selected to render, the post-rendered manifests and the
+// documents selected by each assertion. The suite is selected by its name or the path of its test suite file,
+// all tests of the suite are explained when testName is empty.
+func (tr *TestRunner) ExplainV3(ChartPaths []string, suiteName, testName string) bool {
+	explained := false
+	passed := true
+	for _, chartPath := range ChartPaths {
+		chart, err := v3loader.Load(chartPath)
+		if err != nil {
+			tr.printErroredChartHeader(err)
+			passed = false
+			continue
+		}
+		testSuites, err := tr.getV3TestSuites(chartPath, chart.Name(), chart)
+		if err != nil {
+			tr.printErroredChartHeader(err)
+			passed = false
+			continue
+		}
+
+		for _, suite := range testSuites {
+			if !suite.isNamed(suiteName) {
+				continue
+			}
+			if tr.Deterministic && suite.Functions == nil {
+				suite.Functions = &FunctionStubs{}
+			}
+			suite.polishTestJobsPathInfo()
+			for _, test := range suite.Tests {
+				if test == nil || (testName != "" && test.Name != testName) {
+					continue
+				}
+				if !explained {
+					tr.printChartHeader(chart.Name(), chartPath)
+				}
+				explained = true
+
+				// Each test renders a fresh copy of the chart, the same way as the test run.
+				testChart, err := v3loader.Load(chartPath)
+				if err != nil {
+					tr.printErroredChartHeader(err)
+					passed = false
+					continue
+				}
+				test.WithConfig(*NewTestConfig(testChart, &snapshot.Cache{},
+					WithChartPath(chartPath),
+					WithPostRendererConfig(suite.PostRendererConfig),
+					WithDocumentSelector(test.DocumentSelector),
+				))
+				tr.Printer.Println(fmt.Sprintf("%s %s / %s\t%s",
+					tr.Printer.WarningLabel(" EXPLAIN "), suite.Name, test.Name, tr.Printer.Faint("%s", suite.definitionFile)), 0)
+				if err := test.explainV3(tr.Printer); err != nil {
+					tr.Printer.Println(tr.Printer.Danger("Error: %s", err), 1)
+					passed = false
+				}
+				tr.Printer.Println("", 0)
+			}
+		}
+	}
+
+	if !explained && testName == "" {
+		tr.Printer.Println(tr.Printer.Danger("Error: no suite %q found", suiteName), 0)
+		return false
+	}
+	if !explained {
+		tr.Printer.Println(tr.Printer.Danger("Error: no test %q found in suite %q", testName, suiteName), 0)
+		return false
+	}
+	return passed
+}
+
+// isNamed returns true when the name is the name of the suite or the path of the test suite file,
+// the path can be relative to the chart.
+func (s *TestSuite) isNamed(name string) bool {
+	if name == s.Name || name == filepath.Base(s.definitionFile) {
+		return true
+	}
+	path := filepath.ToSlash(filepath.Clean(name))
+	file := filepath.ToSlash(filepath.Clean(s.definitionFile))
+	return path == file || strings.HasSuffix(file, "/"+path)
+}
+
+// explainV3 renders the test the same way as RunV3, and prints the values, options, templates,
+// manifests and the documents selected by the assertions.
+func (t *TestJob) explainV3(printer *printer.Printer) error {
+	t.determineRenderSuccess()
+	userValues, err := t.getUserValues()
+	if err != nil {
+		return err
+	}
+
+	vals, err := t.renderValuesV3([]byte(userValues))
+	if err != nil {
+		return err
+	}
+	options := t.releaseV3Option()
+	capabilities := t.capabilitiesV3()
+	if err := printExplainYaml(printer, "Release:", map[string]interface{}{
+		"name":      options.Name,
+		"namespace": options.Namespace,
+		"revision":  options.Revision,
+		"upgrade":   options.IsUpgrade,
+	}); err != nil {
+		return err
+	}
+	if err := printExplainYaml(printer, "Capabilities:", map[string]interface{}{
+		"kubeVersion": capabilities.KubeVersion.Version,
+		"apiVersions": []string(capabilities.APIVersions),
+	}); err != nil {
+		return err
+	}
+	if err := printExplainYaml(printer, "Values:", vals["Values"]); err != nil {
+		return err
+	}
+
+	outputOfFiles, renderSucceed, renderError := t.renderV3Chart([]byte(userValues))
+	chart := t.configOrDefault().targetChart
+	filteredChart := CopyV3Chart(t.chartRoute, chart.Name(), t.defaultTemplatesToAssert, t.defaultTemplatesToSkip, chart)
+	printer.Println(printer.Highlight("Templates:"), 1)
+	for _, template := range explainTemplateNames(filteredChart.Name(), filteredChart) {
+		printer.Println("- "+template, 2)
+	}
+	if renderError != nil {
+		printer.Println(printer.Danger("Render error: %s", renderError), 1)
+	} else if !renderSucceed {
+		printer.Println(printer.Danger("Render failed"), 1)
+	}
+
+	postRenderedManifestsOfFiles, didPostRender, err := t.postRender(outputOfFiles)
+	if err != nil {
+		return err
+	}
+	printer.Println(printer.Highlight("Manifests:"), 1)
+	files := make([]string, 0, len(postRenderedManifestsOfFiles))
+	for file, manifest := range postRenderedManifestsOfFiles {
+		if strings.TrimSpace(manifest) != "" {
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		printer.Println(printer.Faint("# Source: %s", file), 2)
+		printExplainLines(printer, strings.TrimSpace(postRenderedManifestsOfFiles[file]), 2)
+	}
+
+	manifestsOfFiles, err := t.parseManifestsFromOutputOfFiles(postRenderedManifestsOfFiles)
+	if err != nil {
+		return err
+	}
+	t.polishAssertionsTemplate(chart.Name(), outputOfFiles)
+	printer.Println(printer.Highlight("Assertions:"), 1)
+	for idx, assertion := range t.Assertions {
+		if assertion == nil {
+			continue
+		}
+		assertion.WithConfig(AssertionConfig{templatesResult: manifestsOfFiles, didPostRender: didPostRender})
+		printer.Println(fmt.Sprintf("- asserts[%d] `%s`", idx, assertion.AssertType), 2)
+		selectedDocsByTemplate, err := assertion.selectDocuments()
+		if err != nil {
+			printer.Println(printer.Danger("Error: %s", err), 3)
+			continue
+		}
+		templates := assertion.getKeys(selectedDocsByTemplate)
+		sort.Strings(templates)
+		if len(templates) == 0 {
+			printer.Println(printer.Faint("no documents selected"), 3)
+		}
+		for _, template := range templates {
+			printer.Println(fmt.Sprintf("%s: documents %v", template,
+				documentIndices(manifestsOfFiles[template], selectedDocsByTemplate[template])), 3)
+		}
+	}
+	return nil
+}
+
+// explainTemplateNames returns the names of the templates of the chart and its dependencies.
+func explainTemplateNames(route string, chart *v3chart.Chart) []string {
+	names := make([]string, 0, len(chart.Templates))
+	for _, template := range chart.Templates {
+		names = append(names, route+"/"+template.Name)
+	}
+	sort.Strings(names)
+	for _, dependency := range chart.Dependencies() {
+		names = append(names, explainTemplateNames(route+"/charts/"+dependency.Name(), dependency)...)
+	}
+	return names
+}
+
+// printExplainYaml prints the title followed by the value as YAML.
+func printExplainYaml(printer *printer.Printer, title string, value interface{}) error {
+	content := new(bytes.Buffer)
+	yamlEncoder := common.YamlNewEncoder(content)
+	yamlEncoder.SetIndent(common.YAMLINDENTION)
+	if err := yamlEncoder.Encode(value); err != nil {
+		return err
+	}
+	printer.Println(printer.Highlight("%s", title), 1)
+	printExplainLines(printer, strings.TrimSpace(content.String()), 2)
+	return nil
+}
+
+// printExplainLines prints each line of the content with the indent level.
+func printExplainLines(printer *printer.Printer, content string, indentLevel int) {
+	for _, line := range strings.Split(content, "\n") {
+		printer.Println(line, indentLevel)
+	}
+}
@@ -0,0 +1,57 @@
+package unittest_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest"
+)
+
+func TestExplainV3(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "basic")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS(testV3BasicChart)))
+	assert.NoError(t, os.RemoveAll(filepath.Join(chartPath, "tests")))
+	assert.NoError(t, os.Mkdir(filepath.Join(chartPath, "tests"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "service_test.yaml"), []byte(`suite: explain
+templates:
+  - service.yaml
+release:
+  name: my-release
+tests:
+  - it: should explain
+    set:
+      service.externalPort: 8080
+    asserts:
+      - equal:
+          path: spec.ports[0].port
+          value: 1
+  - it: should not be explained
+    asserts:
+      - hasDocuments:
+          count: 1
+`), 0644))
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/*_test.yaml"},
+	}
+	assert.True(t, runner.ExplainV3([]string{chartPath}, "tests/service_test.yaml", "should explain"), buffer.String())
+	output := buffer.String()
+	assert.Contains(t, output, " EXPLAIN  explain / should explain")
+	assert.NotContains(t, output, "should not be explained")
+	assert.Contains(t, output, "\tRelease:\n\t\tname: my-release\n\t\tnamespace: NAMESPACE\n")
+	assert.Contains(t, output, "\t\t  externalPort: 8080\n")
+	assert.Contains(t, output, "\tTemplates:\n\t\t- basic/templates/_helpers.tpl\n\t\t- basic/templates/service.yaml\n")
+	assert.Contains(t, output, "\t\t# Source: basic/templates/service.yaml\n")
+	assert.Contains(t, output, "\t\t- asserts[0] `equal`\n\t\t\tbasic/templates/service.yaml: documents [0]\n")
+
+	buffer.Reset()
+	assert.False(t, runner.ExplainV3([]string{chartPath}, "explain", "missing"))
+	assert.Equal(t, "Error: no test \"missing\" found in suite \"explain\"\n", buffer.String())
+}
@@ -0,0 +1,210 @@
+package unittest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	"gopkg.in/yaml.v3"
+)
+
+// FormatOptions the options to format the test suite files of a chart
+type FormatOptions struct {
+	// TestFiles the patterns of the test suite files, relative to the chart
+	TestFiles []string
+	// Check reports the test suite files to format, instead of writing the formatted test suite files
+	Check bool
+}
+
+// FormatResult the test suite files formatted and unchanged by the format
+type FormatResult struct {
+	Formatted []string
+	Unchanged []string
+}
+
+// FormatV3 formats the test suite files of the chart. The test suites are written with an indentation of two
+// spaces and sequences indented within mappings, the comments, order of the keys, styles of the values and
+// the blank lines between the entries are kept. The test suite files which fail to parse are not formatted,
+// and returned as error.
+func FormatV3(chartPath string, options FormatOptions) (*FormatResult, error) {
+	testFilesSet, err := GetFiles(chartPath, options.TestFiles, false)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &FormatResult{}
+	var formatErrs []error
+	for _, file := range testFilesSet {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		formatted, err := FormatTestSuite(content)
+		if err != nil {
+			formatErrs = append(formatErrs, fmt.Errorf("failed to format %s: %w", file, err))
+			continue
+		}
+		if bytes.Equal(content, formatted) {
+			result.Unchanged = append(result.Unchanged, file)
+			continue
+		}
+		if !options.Check {
+			if err := os.WriteFile(file, formatted, 0644); err != nil {
+				return nil, err
+			}
+		}
+		result.Formatted = append(result.Formatted, file)
+	}
+	return result, errors.Join(formatErrs...)
+}
+
+// FormatTestSuite returns the formatted content of a test suite file, the test suites are separated by `---`.
+func FormatTestSuite(content []byte) ([]byte, error) {
+	decoder := common.YamlNewDecoder(bytes.NewReader(content))
+	byteBuffer := new(bytes.Buffer)
+	yamlEncoder := common.YamlNewEncoder(byteBuffer)
+	yamlEncoder.SetIndent(common.YAMLINDENTION)
+	lines := strings.Split(string(content), "\n")
+	documents := make([]*yaml.Node, 0)
+	for {
+		var document yaml.Node
+		if err := decoder.Decode(&document); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if len(document.Content) == 0 {
+			continue
+		}
+		reattachFootComments(&document, lines)
+		if err := yamlEncoder.Encode(&document); err != nil {
+			return nil, err
+		}
+		documents = append(documents, &document)
+	}
+	if err := yamlEncoder.Close(); err != nil {
+		return nil, err
+	}
+	return keepBlankLines(lines, byteBuffer.Bytes(), documents)
+}
+
+// reattachFootComments moves the comment lines directly above an entry, which are decoded as foot comment of
+// the last value of the previous entry, to the entry. The comment is written above the entry, instead of
+// below the value with the indentation of the value.
+func reattachFootComments(node *yaml.Node, lines []string) {
+	step := 1
+	if node.Kind == yaml.MappingNode {
+		step = 2
+	}
+	for idx := step; idx < len(node.Content) && node.Kind != yaml.DocumentNode; idx += step {
+		entry := node.Content[idx]
+		comment := commentAbove(lines, entry.Line)
+		if entry.HeadComment != "" || comment == "" {
+			continue
+		}
+		for _, previous := range node.Content[idx-step : idx] {
+			if holder := footCommentHolder(previous, comment); holder != nil {
+				holder.FootComment = strings.TrimRight(strings.TrimSuffix(holder.FootComment, comment), "\n")
+				entry.HeadComment = comment
+				break
+			}
+		}
+	}
+	for _, child := range node.Content {
+		reattachFootComments(child, lines)
+	}
+}
+
+// commentAbove returns the comment lines directly above the line, empty when the line above is no comment.
+func commentAbove(lines []string, line int) string {
+	start := line - 1
+	for start > 0 && start-1 < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[start-1]), "#") {
+		start--
+	}
+	if start == line-1 {
+		return ""
+	}
+	comment := make([]string, 0, line-1-start)
+	for _, commentLine := range lines[start : line-1] {
+		comment = append(comment, strings.TrimSpace(commentLine))
+	}
+	return strings.Join(comment, "\n")
+}
+
+// footCommentHolder returns the node which has the comment at the end of the foot comment, nil when not found.
+func footCommentHolder(node *yaml.Node, comment string) *yaml.Node {
+	if strings.HasSuffix(node.FootComment, comment) {
+		return node
+	}
+	for idx := len(node.Content) - 1; idx >= 0; idx-- {
+		if holder := footCommentHolder(node.Content[idx], comment); holder != nil {
+			return holder
+		}
+	}
+	return nil
+}
+
+// keepBlankLines inserts a blank line in the formatted content before each entry, which follows a blank line
+// in the content. The entries are located by decoding the formatted content, which has the same nodes as the
+// documents of the content.
+func keepBlankLines(lines []string, formatted []byte, documents []*yaml.Node) ([]byte, error) {
+	blankBefore := func(line int) bool {
+		return line >= 2 && line-2 < len(lines) && strings.TrimSpace(lines[line-2]) == ""
+	}
+
+	decoder := common.YamlNewDecoder(bytes.NewReader(formatted))
+	insertBefore := make(map[int]bool)
+	for _, document := range documents {
+		var formattedDocument yaml.Node
+		if err := decoder.Decode(&formattedDocument); err != nil {
+			return nil, err
+		}
+		var walk func(node, formattedNode *yaml.Node)
+		walk = func(node, formattedNode *yaml.Node) {
+			if node.Style&yaml.FlowStyle != 0 || len(node.Content) != len(formattedNode.Content) {
+				return
+			}
+			step := 1
+			if node.Kind == yaml.MappingNode {
+				step = 2
+			}
+			for idx := 0; idx < len(node.Content); idx++ {
+				if (node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode) && idx%step == 0 && idx > 0 {
+					entry, formattedEntry := node.Content[idx], formattedNode.Content[idx]
+					if blankBefore(entry.Line - commentLines(entry.HeadComment)) {
+						insertBefore[formattedEntry.Line-commentLines(formattedEntry.HeadComment)] = true
+					}
+				}
+				walk(node.Content[idx], formattedNode.Content[idx])
+			}
+		}
+		walk(document, &formattedDocument)
+	}
+
+	formattedLines := strings.Split(string(formatted), "\n")
+	result := make([]string, 0, len(formattedLines)+len(insertBefore))
+	for idx, line := range formattedLines {
+		// The documents with only a comment are written with blank lines after the separator.
+		if line == "" && idx < len(formattedLines)-1 && len(result) > 0 && result[len(result)-1] == "---" {
+			continue
+		}
+		if insertBefore[idx+1] {
+			result = append(result, "")
+		}
+		result = append(result, line)
+	}
+	return []byte(strings.Join(result, "\n")), nil
+}
+
+// commentLines returns the number of lines of the comment.
+func commentLines(comment string) int {
+	if comment == "" {
+		return 0
+	}
+	return strings.Count(comment, "\n") + 1
+}
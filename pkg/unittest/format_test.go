@@ -0,0 +1,101 @@
+package unittest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest"
+	"github.com/stretchr/testify/assert"
+)
+
+const unformattedSuite = `---
+suite:   format
+templates:
+- service.yaml
+tests:
+    - it: should render # the first test
+      asserts:
+      - isKind: {of: Service}
+
+# the second test
+    - it: should render again
+      asserts:
+      - equal:
+            path: metadata.name
+            value: "RELEASE-NAME-basic"
+---
+suite: format again
+tests:
+- it: should render
+  asserts:
+  - hasDocuments:
+      count: 1
+`
+
+const formattedSuite = `suite: format
+templates:
+  - service.yaml
+tests:
+  - it: should render # the first test
+    asserts:
+      - isKind: {of: Service}
+
+  # the second test
+  - it: should render again
+    asserts:
+      - equal:
+          path: metadata.name
+          value: "RELEASE-NAME-basic"
+---
+suite: format again
+tests:
+  - it: should render
+    asserts:
+      - hasDocuments:
+          count: 1
+`
+
+func TestFormatTestSuite(t *testing.T) {
+	formatted, err := FormatTestSuite([]byte(unformattedSuite))
+	assert.NoError(t, err)
+	assert.Equal(t, formattedSuite, string(formatted))
+
+	formatted, err = FormatTestSuite(formatted)
+	assert.NoError(t, err)
+	assert.Equal(t, formattedSuite, string(formatted))
+
+	_, err = FormatTestSuite([]byte("suite: [format\n"))
+	assert.Error(t, err)
+}
+
+func TestFormatV3(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "basic")
+	suiteFile := filepath.Join(chartPath, "tests", "format_test.yaml")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(suiteFile), 0755))
+	assert.NoError(t, os.WriteFile(suiteFile, []byte(unformattedSuite), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "formatted_test.yaml"), []byte(formattedSuite), 0644))
+	options := FormatOptions{TestFiles: []string{"tests/*_test.yaml"}, Check: true}
+
+	// The test suite files are not written when checking.
+	result, err := FormatV3(chartPath, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{suiteFile}, result.Formatted)
+	assert.Len(t, result.Unchanged, 1)
+	content, err := os.ReadFile(suiteFile)
+	assert.NoError(t, err)
+	assert.Equal(t, unformattedSuite, string(content))
+
+	options.Check = false
+	result, err = FormatV3(chartPath, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{suiteFile}, result.Formatted)
+	content, err = os.ReadFile(suiteFile)
+	assert.NoError(t, err)
+	assert.Equal(t, formattedSuite, string(content))
+
+	result, err = FormatV3(chartPath, options)
+	assert.NoError(t, err)
+	assert.Empty(t, result.Formatted)
+	assert.Len(t, result.Unchanged, 2)
+}
@@ -0,0 +1,441 @@
+package unittest
+
+import (
+	"cmp"
+	"crypto"
+	"crypto/dsa"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"net"
+	"text/template"
+	"time"
+)
+
+const defaultStubNow = "2024-01-01T00:00:00Z"
+
+const (
+	alphaCharacters   = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	numericCharacters = "0123456789"
+)
+
+// FunctionStubs replaces the non-deterministic template functions (like randAlphaNum, uuidv4, now and genCA)
+// with seeded or fixed implementations, so the rendered output is reproducible.
+type FunctionStubs struct {
+	Now      string `yaml:"now"`
+	RandSeed int64  `yaml:"randSeed"`
+}
+
+// functionStubber implements the stubbed functions, which are evaluated when the templates are rendered.
+// A stubber is created for every render, so the generated values only depend on the seed and the order of the calls.
+type functionStubber struct {
+	now time.Time
+	rng *rand.Rand
+}
+
+// stubCertificate the certificate returned by the stubbed genCA and gen*Cert functions, like the certificate of sprig.
+type stubCertificate struct {
+	Cert string
+	Key  string
+}
+
+func newFunctionStubber(stubs *FunctionStubs) (*functionStubber, error) {
+	now, err := time.Parse(time.RFC3339, cmp.Or(stubs.Now, defaultStubNow))
+	if err != nil {
+		return nil, fmt.Errorf("functions.now: %w", err)
+	}
+	return &functionStubber{now: now, rng: rand.New(rand.NewSource(stubs.RandSeed))}, nil
+}
+
+// funcMap returns the stubbed functions, which override the functions of the engine with the same name.
+func (s *functionStubber) funcMap() template.FuncMap {
+	return template.FuncMap{
+		"now":                      func() time.Time { return s.now },
+		"randAlphaNum":             s.randomString(alphaCharacters + numericCharacters),
+		"randAlpha":                s.randomString(alphaCharacters),
+		"randNumeric":              s.randomString(numericCharacters),
+		"randAscii":                s.randomString(asciiCharacters()),
+		"randBytes":                s.randomBytes,
+		"uuidv4":                   s.uuid,
+		"genPrivateKey":            s.privateKey,
+		"buildCustomCert":          buildCustomCertificate,
+		"genCA":                    s.generateCA,
+		"genCAWithKey":             s.generateCAWithKey,
+		"genSelfSignedCert":        s.generateSelfSignedCertificate,
+		"genSelfSignedCertWithKey": s.generateSelfSignedCertificateWithKey,
+		"genSignedCert":            s.generateSignedCertificate,
+		"genSignedCertWithKey":     s.generateSignedCertificateWithKey,
+	}
+}
+
+func (s *functionStubber) randomString(characters string) func(count int) string {
+	return func(count int) string {
+		if count <= 0 {
+			return ""
+		}
+		random := make([]byte, count)
+		for idx := range random {
+			random[idx] = characters[s.rng.Intn(len(characters))]
+		}
+		return string(random)
+	}
+}
+
+func (s *functionStubber) randomBytes(count int) (string, error) {
+	if count < 0 {
+		return "", fmt.Errorf("randBytes: negative count %d", count)
+	}
+	random := make([]byte, count)
+	_, _ = s.rng.Read(random)
+	return base64.StdEncoding.EncodeToString(random), nil
+}
+
+func (s *functionStubber) uuid() string {
+	uuid := make([]byte, 16)
+	_, _ = s.rng.Read(uuid)
+	uuid[6] = (uuid[6] & 0x0f) | 0x40
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:])
+}
+
+// privateKey generates the private key of the type, the keys and errors are formatted like genPrivateKey of sprig.
+func (s *functionStubber) privateKey(typ string) string {
+	var key crypto.Signer
+	var err error
+	switch typ {
+	case "", "rsa":
+		key, err = s.generateRSAKey(4096)
+	case "dsa":
+		return s.generateDSAKey()
+	case "ecdsa":
+		key = s.generateECDSAKey()
+	case "ed25519":
+		key = s.generateEd25519Key()
+	default:
+		return "Unknown type " + typ
+	}
+	if err != nil {
+		return fmt.Sprintf("failed to generate private key: %s", err)
+	}
+
+	block, err := pemBlockForKey(key)
+	if err != nil {
+		return err.Error()
+	}
+	return string(pem.EncodeToMemory(block))
+}
+
+// generateRSAKey generates the primes from the seeded random source, as rsa.GenerateKey does not use the passed source.
+func (s *functionStubber) generateRSAKey(bits int) (*rsa.PrivateKey, error) {
+	e := big.NewInt(65537)
+	one := big.NewInt(1)
+	for {
+		p := s.prime(bits - bits/2)
+		q := s.prime(bits / 2)
+		if p.Cmp(q) == 0 {
+			continue
+		}
+		n := new(big.Int).Mul(p, q)
+		if n.BitLen() != bits {
+			continue
+		}
+		totient := new(big.Int).Mul(new(big.Int).Sub(p, one), new(big.Int).Sub(q, one))
+		d := new(big.Int).ModInverse(e, totient)
+		if d == nil {
+			continue
+		}
+
+		key := &rsa.PrivateKey{
+			PublicKey: rsa.PublicKey{N: n, E: int(e.Int64())},
+			D:         d,
+			Primes:    []*big.Int{p, q},
+		}
+		key.Precompute()
+		return key, key.Validate()
+	}
+}
+
+// prime returns a random prime of the bits, with the two most significant bits set.
+func (s *functionStubber) prime(bits int) *big.Int {
+	random := make([]byte, (bits+7)/8)
+	for {
+		_, _ = s.rng.Read(random)
+		candidate := new(big.Int).SetBytes(random)
+		candidate.Rsh(candidate, uint(len(random)*8-bits))
+		candidate.SetBit(candidate, bits-1, 1)
+		candidate.SetBit(candidate, bits-2, 1)
+		candidate.SetBit(candidate, 0, 1)
+		if candidate.ProbablyPrime(20) {
+			return candidate
+		}
+	}
+}
+
+// generateDSAKey generates the dsa key, which can not sign certificates so it is only returned as pem.
+func (s *functionStubber) generateDSAKey() string {
+	key := new(dsa.PrivateKey)
+	if err := dsa.GenerateParameters(&key.Parameters, s.rng, dsa.L2048N256); err != nil {
+		return fmt.Sprintf("failed to generate private key: %s", err)
+	}
+	if err := dsa.GenerateKey(key, s.rng); err != nil {
+		return fmt.Sprintf("failed to generate private key: %s", err)
+	}
+
+	type dsaKeyFormat struct {
+		Version       int
+		P, Q, G, Y, X *big.Int
+	}
+	bytes, err := asn1.Marshal(dsaKeyFormat{P: key.P, Q: key.Q, G: key.G, Y: key.Y, X: key.X})
+	if err != nil {
+		return fmt.Sprintf("failed to generate private key: %s", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "DSA PRIVATE KEY", Bytes: bytes}))
+}
+
+// generateECDSAKey generates the private scalar from the seeded random source, as ecdsa.GenerateKey does not use the passed source.
+func (s *functionStubber) generateECDSAKey() *ecdsa.PrivateKey {
+	curve := elliptic.P256()
+	scalar := make([]byte, 32)
+	for {
+		_, _ = s.rng.Read(scalar)
+		d := new(big.Int).SetBytes(scalar)
+		if d.Sign() == 0 || d.Cmp(curve.Params().N) >= 0 {
+			continue
+		}
+		x, y := curve.ScalarBaseMult(d.FillBytes(make([]byte, 32)))
+		return &ecdsa.PrivateKey{PublicKey: ecdsa.PublicKey{Curve: curve, X: x, Y: y}, D: d}
+	}
+}
+
+func (s *functionStubber) generateEd25519Key() ed25519.PrivateKey {
+	seed := make([]byte, ed25519.SeedSize)
+	_, _ = s.rng.Read(seed)
+	return ed25519.NewKeyFromSeed(seed)
+}
+
+func (s *functionStubber) generateCA(cn string, daysValid int) (stubCertificate, error) {
+	key, err := s.generateRSAKey(2048)
+	if err != nil {
+		return stubCertificate{}, fmt.Errorf("error generating rsa key: %w", err)
+	}
+	return s.generateCAWithSigner(cn, daysValid, key)
+}
+
+func (s *functionStubber) generateCAWithKey(cn string, daysValid int, keyPEM string) (stubCertificate, error) {
+	key, err := parsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return stubCertificate{}, fmt.Errorf("parsing private key: %w", err)
+	}
+	return s.generateCAWithSigner(cn, daysValid, key)
+}
+
+func (s *functionStubber) generateCAWithSigner(cn string, daysValid int, key crypto.Signer) (stubCertificate, error) {
+	template, err := s.certificateTemplate(cn, nil, nil, daysValid)
+	if err != nil {
+		return stubCertificate{}, err
+	}
+	template.KeyUsage |= x509.KeyUsageCertSign
+	template.IsCA = true
+	return createCertificate(template, template, key, key)
+}
+
+func (s *functionStubber) generateSelfSignedCertificate(cn string, ips, alternateDNS []interface{}, daysValid int) (stubCertificate, error) {
+	key, err := s.generateRSAKey(2048)
+	if err != nil {
+		return stubCertificate{}, fmt.Errorf("error generating rsa key: %w", err)
+	}
+	return s.generateSelfSignedCertificateWithSigner(cn, ips, alternateDNS, daysValid, key)
+}
+
+func (s *functionStubber) generateSelfSignedCertificateWithKey(cn string, ips, alternateDNS []interface{}, daysValid int, keyPEM string) (stubCertificate, error) {
+	key, err := parsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return stubCertificate{}, fmt.Errorf("parsing private key: %w", err)
+	}
+	return s.generateSelfSignedCertificateWithSigner(cn, ips, alternateDNS, daysValid, key)
+}
+
+func (s *functionStubber) generateSelfSignedCertificateWithSigner(cn string, ips, alternateDNS []interface{}, daysValid int, key crypto.Signer) (stubCertificate, error) {
+	template, err := s.certificateTemplate(cn, ips, alternateDNS, daysValid)
+	if err != nil {
+		return stubCertificate{}, err
+	}
+	return createCertificate(template, template, key, key)
+}
+
+func (s *functionStubber) generateSignedCertificate(cn string, ips, alternateDNS []interface{}, daysValid int, ca stubCertificate) (stubCertificate, error) {
+	key, err := s.generateRSAKey(2048)
+	if err != nil {
+		return stubCertificate{}, fmt.Errorf("error generating rsa key: %w", err)
+	}
+	return s.generateSignedCertificateWithSigner(cn, ips, alternateDNS, daysValid, ca, key)
+}
+
+func (s *functionStubber) generateSignedCertificateWithKey(cn string, ips, alternateDNS []interface{}, daysValid int, ca stubCertificate, keyPEM string) (stubCertificate, error) {
+	key, err := parsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return stubCertificate{}, fmt.Errorf("parsing private key: %w", err)
+	}
+	return s.generateSignedCertificateWithSigner(cn, ips, alternateDNS, daysValid, ca, key)
+}
+
+func (s *functionStubber) generateSignedCertificateWithSigner(cn string, ips, alternateDNS []interface{}, daysValid int, ca stubCertificate, key crypto.Signer) (stubCertificate, error) {
+	parent, caKey, err := parseCertificate(ca)
+	if err != nil {
+		return stubCertificate{}, err
+	}
+	template, err := s.certificateTemplate(cn, ips, alternateDNS, daysValid)
+	if err != nil {
+		return stubCertificate{}, err
+	}
+	return createCertificate(template, parent, key, caKey)
+}
+
+// certificateTemplate returns the template of the certificate, valid from the stubbed now.
+func (s *functionStubber) certificateTemplate(cn string, ips, alternateDNS []interface{}, daysValid int) (*x509.Certificate, error) {
+	template := &x509.Certificate{
+		SerialNumber:          new(big.Int).Rand(s.rng, new(big.Int).Lsh(big.NewInt(1), 128)),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             s.now,
+		NotAfter:              s.now.Add(time.Duration(daysValid) * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+
+	for _, ip := range ips {
+		text, ok := ip.(string)
+		if !ok {
+			return nil, fmt.Errorf("error parsing ip: %v is not a string", ip)
+		}
+		parsed := net.ParseIP(text)
+		if parsed == nil {
+			return nil, fmt.Errorf("error parsing ip: %s", text)
+		}
+		template.IPAddresses = append(template.IPAddresses, parsed)
+	}
+	for _, dns := range alternateDNS {
+		text, ok := dns.(string)
+		if !ok {
+			return nil, fmt.Errorf("error processing alternate dns name: %v is not a string", dns)
+		}
+		template.DNSNames = append(template.DNSNames, text)
+	}
+	return template, nil
+}
+
+// createCertificate signs the certificate without random source, which makes the signature deterministic.
+func createCertificate(template, parent *x509.Certificate, key, signer crypto.Signer) (stubCertificate, error) {
+	certificate, err := x509.CreateCertificate(nil, template, parent, key.Public(), signer)
+	if err != nil {
+		return stubCertificate{}, fmt.Errorf("error creating certificate: %w", err)
+	}
+	block, err := pemBlockForKey(key)
+	if err != nil {
+		return stubCertificate{}, err
+	}
+	return stubCertificate{
+		Cert: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate})),
+		Key:  string(pem.EncodeToMemory(block)),
+	}, nil
+}
+
+// buildCustomCertificate returns the certificate of the base64 encoded certificate and key, so it can be passed
+// to the stubbed genSignedCert functions.
+func buildCustomCertificate(b64cert, b64key string) (stubCertificate, error) {
+	cert, err := base64.StdEncoding.DecodeString(b64cert)
+	if err != nil {
+		return stubCertificate{}, errors.New("unable to decode base64 certificate")
+	}
+	key, err := base64.StdEncoding.DecodeString(b64key)
+	if err != nil {
+		return stubCertificate{}, errors.New("unable to decode base64 private key")
+	}
+
+	certificate := stubCertificate{Cert: string(cert), Key: string(key)}
+	if _, _, err := parseCertificate(certificate); err != nil {
+		return stubCertificate{}, err
+	}
+	return certificate, nil
+}
+
+func parseCertificate(certificate stubCertificate) (*x509.Certificate, crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(certificate.Cert))
+	if block == nil {
+		return nil, nil, errors.New("error decoding certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing certificate: %w", err)
+	}
+	key, err := parsePrivateKeyPEM(certificate.Key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing private key: %w", err)
+	}
+	return cert, key, nil
+}
+
+func parsePrivateKeyPEM(keyPEM string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, errors.New("no PEM data in input")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+	return signer, nil
+}
+
+func pemBlockForKey(key crypto.Signer) (*pem.Block, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)}, nil
+	case *ecdsa.PrivateKey:
+		bytes, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return nil, err
+		}
+		return &pem.Block{Type: "EC PRIVATE KEY", Bytes: bytes}, nil
+	default:
+		bytes, err := x509.MarshalPKCS8PrivateKey(k)
+		if err != nil {
+			return nil, err
+		}
+		return &pem.Block{Type: "PRIVATE KEY", Bytes: bytes}, nil
+	}
+}
+
+func asciiCharacters() string {
+	var characters []byte
+	for character := byte(32); character <= 126; character++ {
+		characters = append(characters, character)
+	}
+	return string(characters)
+}
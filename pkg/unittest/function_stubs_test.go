@@ -0,0 +1,101 @@
+package unittest
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func renderWithStubs(t *testing.T, stubs *FunctionStubs, text string, data interface{}) string {
+	t.Helper()
+	stubber, err := newFunctionStubber(stubs)
+	assert.NoError(t, err)
+
+	tpl, err := template.New("a.yaml").Funcs(sprig.TxtFuncMap()).Funcs(stubber.funcMap()).Parse(text)
+	assert.NoError(t, err)
+	var builder strings.Builder
+	assert.NoError(t, tpl.Execute(&builder, data))
+	return builder.String()
+}
+
+func TestStubbedNowIsTheConfiguredTime(t *testing.T) {
+	assert.Equal(t, "2030 2030-06-15",
+		renderWithStubs(t, &FunctionStubs{Now: "2030-06-15T12:00:00Z"}, `{{ now.Year }} {{ now | date "2006-01-02" }}`, nil))
+}
+
+func TestStubbedFunctionsAreReproducible(t *testing.T) {
+	text := `{{ randAlphaNum 10 }} {{ uuidv4 }}`
+
+	first := renderWithStubs(t, &FunctionStubs{RandSeed: 42}, text, nil)
+	assert.Equal(t, first, renderWithStubs(t, &FunctionStubs{RandSeed: 42}, text, nil))
+	assert.NotEqual(t, first, renderWithStubs(t, &FunctionStubs{RandSeed: 7}, text, nil))
+}
+
+func TestStubbedFunctionsAreEvaluatedForEveryCall(t *testing.T) {
+	rendered := renderWithStubs(t, &FunctionStubs{}, `{{ range . }}{{ randAlphaNum 8 }},{{ end }}`, []int{1, 2, 3})
+
+	values := strings.Split(strings.TrimSuffix(rendered, ","), ",")
+	assert.Len(t, values, 3)
+	assert.NotEqual(t, values[0], values[1])
+	assert.NotEqual(t, values[1], values[2])
+}
+
+func TestStubbedRandomFunctionsHonorTheLength(t *testing.T) {
+	rendered := renderWithStubs(t, &FunctionStubs{}, `{{ randAlphaNum 2000 }}|{{ randNumeric 5 }}|{{ randBytes 12 | b64dec }}`, nil)
+
+	values := strings.Split(rendered, "|")
+	assert.Len(t, values[0], 2000)
+	assert.Regexp(t, "^[0-9]{5}$", values[1])
+	assert.Len(t, values[2], 12)
+}
+
+func TestStubbedGenCAHonorsTheArguments(t *testing.T) {
+	rendered := renderWithStubs(t, &FunctionStubs{}, `{{ $ca := genCA "my-ca" 10 }}{{ $ca.Cert }}`, nil)
+
+	block, _ := pem.Decode([]byte(rendered))
+	assert.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	assert.NoError(t, err)
+	assert.Equal(t, "my-ca", cert.Subject.CommonName)
+	assert.True(t, cert.IsCA)
+	assert.Equal(t, time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), cert.NotAfter.UTC())
+}
+
+func TestStubbedGenSignedCertIsSignedByTheCA(t *testing.T) {
+	rendered := renderWithStubs(t, &FunctionStubs{},
+		`{{ $ca := genCA "my-ca" 10 }}{{ $cert := genSignedCert "my-service" (list "10.0.0.1") (list "my-service.svc") 5 $ca }}{{ $ca.Cert }}{{ $cert.Cert }}`, nil)
+
+	caBlock, rest := pem.Decode([]byte(rendered))
+	certBlock, _ := pem.Decode(rest)
+	ca, err := x509.ParseCertificate(caBlock.Bytes)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	assert.NoError(t, err)
+
+	a := assert.New(t)
+	a.NoError(cert.CheckSignatureFrom(ca))
+	a.Equal("my-service", cert.Subject.CommonName)
+	a.Equal([]string{"my-service.svc"}, cert.DNSNames)
+	a.Equal("10.0.0.1", cert.IPAddresses[0].String())
+}
+
+func TestStubbedGenPrivateKeyHonorsTheType(t *testing.T) {
+	rendered := renderWithStubs(t, &FunctionStubs{}, `{{ genPrivateKey "ecdsa" }}{{ genPrivateKey "ed25519" }}{{ genPrivateKey "foo" }}`, nil)
+
+	ecdsaBlock, rest := pem.Decode([]byte(rendered))
+	ed25519Block, rest := pem.Decode(rest)
+	assert.Equal(t, "EC PRIVATE KEY", ecdsaBlock.Type)
+	assert.Equal(t, "PRIVATE KEY", ed25519Block.Type)
+	assert.Equal(t, "Unknown type foo", string(rest))
+}
+
+func TestNewFunctionStubberWithInvalidNow(t *testing.T) {
+	_, err := newFunctionStubber(&FunctionStubs{Now: "yesterday"})
+	assert.ErrorContains(t, err, "functions.now")
+}
@@ -0,0 +1,304 @@
+package unittest
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/results"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	v3chart "helm.sh/helm/v3/pkg/chart"
+	v3loader "helm.sh/helm/v3/pkg/chart/loader"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// maxShrinkAttempts the number of renders to minimize the values of a failed iteration
+const maxShrinkAttempts = 500
+
+// FuzzOptions the options of the property-based testing of the values of the charts
+type FuzzOptions struct {
+	// Seed the seed of the generated values, the same seed generates the same values
+	Seed int64
+	// Iterations the number of generated values per chart
+	Iterations int
+	// FailuresPath the folder where the minimized values of the failed iterations are written to
+	FailuresPath string
+}
+
+// fuzzFailure the check which failed for generated values
+type fuzzFailure struct {
+	check   string
+	message string
+}
+
+// chartFuzzer renders a chart with generated values and checks the rendered output
+type chartFuzzer struct {
+	chartPath string
+	// the test suites of the chart run with the generated values
+	suites []*TestSuite
+}
+
+// FuzzV3 renders the charts with random values which meet the values.schema.json of the chart, and checks the render
+// succeeds, the rendered output parses as YAML, every document has an apiVersion, kind and metadata.name, and the tests
+// of the suites with fuzz pass. The values of failed iterations are minimized and written to the failures path.
+func (tr *TestRunner) FuzzV3(chartPaths []string, options FuzzOptions) bool {
+	passed := true
+	for _, chartPath := range chartPaths {
+		chart, err := v3loader.Load(chartPath)
+		if err != nil {
+			tr.printErroredChartHeader(err)
+			passed = false
+			continue
+		}
+		tr.printChartHeader(chart.Name(), chartPath)
+		if len(chart.Schema) == 0 {
+			tr.Printer.Println(tr.Printer.Warning("No values.schema.json, the values of the chart can not be generated"), 1)
+			continue
+		}
+		chartPassed, err := tr.fuzzChart(chartPath, chart, options)
+		if err != nil {
+			tr.Printer.Println(tr.Printer.Danger("Error: %s", err), 1)
+		}
+		passed = passed && chartPassed && err == nil
+		if !passed && tr.Failfast {
+			break
+		}
+	}
+	return passed
+}
+
+// fuzzChart runs the iterations of the chart, and prints the failed iterations and the summary of the chart.
+func (tr *TestRunner) fuzzChart(chartPath string, chart *v3chart.Chart, options FuzzOptions) (bool, error) {
+	generator, err := newValuesGenerator(chart.Schema, options.Seed)
+	if err != nil {
+		return false, err
+	}
+	testSuites, err := tr.getTestSuites(chartPath, chart.Name())
+	if err != nil {
+		return false, err
+	}
+	fuzzer := &chartFuzzer{chartPath: chartPath}
+	skippedSnapshots := 0
+	for _, suite := range testSuites {
+		if !suite.Fuzz {
+			continue
+		}
+		// The generated values have to render the same output each time.
+		if suite.Functions == nil {
+			suite.Functions = &FunctionStubs{}
+		}
+		suite.polishTestJobsPathInfo()
+		for _, test := range suite.Tests {
+			if test != nil {
+				skippedSnapshots += removeSnapshotAssertions(test)
+			}
+		}
+		fuzzer.suites = append(fuzzer.suites, suite)
+	}
+	if skippedSnapshots > 0 {
+		tr.Printer.Println(tr.Printer.Warning("Skipped %d snapshot assertions, the snapshots do not apply to generated values", skippedSnapshots), 1)
+	}
+
+	failed, invalid := 0, 0
+	for iteration := 1; iteration <= options.Iterations; iteration++ {
+		values := generator.generateValues()
+		failure, isInvalid := fuzzer.check(values)
+		if isInvalid {
+			invalid++
+			continue
+		}
+		if failure == nil {
+			continue
+		}
+		failed++
+		minimized := fuzzer.minimize(values, failure)
+		file, err := writeFuzzFailure(chart.Name(), iteration, options, failure, minimized)
+		if err != nil {
+			return false, err
+		}
+		tr.Printer.Println(fmt.Sprintf("%s iteration %d: %s", tr.Printer.DangerLabel(" FAIL "), iteration, failure.check), 1)
+		printExplainLines(tr.Printer, failure.message, 2)
+		tr.Printer.Println("Values:\t"+file, 2)
+		tr.Printer.Println("", 0)
+		if tr.Failfast {
+			break
+		}
+	}
+
+	summary := fmt.Sprintf("Fuzz: %d iterations with seed %d, %d failed, %d invalid values generated",
+		options.Iterations, options.Seed, failed, invalid)
+	if failed > 0 {
+		tr.Printer.Println(tr.Printer.Danger("%s", summary), 1)
+	} else {
+		tr.Printer.Println(tr.Printer.Success("%s", summary), 1)
+	}
+	return failed == 0, nil
+}
+
+// removeSnapshotAssertions removes the assertions compared with the snapshot file from the test, and returns the
+// number of removed assertions.
+func removeSnapshotAssertions(test *TestJob) int {
+	count := len(test.Assertions)
+	test.Assertions = slices.DeleteFunc(test.Assertions, func(assertion *Assertion) bool {
+		return assertion != nil && (assertion.AssertType == "matchSnapshot" || assertion.AssertType == "matchSnapshotRaw")
+	})
+	return count - len(test.Assertions)
+}
+
+// check renders the chart with the values and returns the failed check, nil when all checks passed.
+// The values which do not meet the schema, as the generator does not support all keywords, are invalid.
+func (f *chartFuzzer) check(values map[string]interface{}) (*fuzzFailure, bool) {
+	chart, err := loadChartSilently(f.chartPath)
+	if err != nil {
+		return &fuzzFailure{check: "load", message: err.Error()}, false
+	}
+	job := &TestJob{
+		Name:                 "fuzz",
+		Functions:            &FunctionStubs{},
+		chartRoute:           chart.Name(),
+		definitionFile:       filepath.Join(f.chartPath, "Chart.yaml"),
+		fuzzValues:           values,
+		requireRenderSuccess: true,
+	}
+	job.WithConfig(*NewTestConfig(chart, &snapshot.Cache{}, WithChartPath(f.chartPath)))
+	userValues, err := job.getUserValues()
+	if err != nil {
+		return &fuzzFailure{check: "values", message: err.Error()}, false
+	}
+	outputOfFiles, _, err := job.renderV3Chart([]byte(userValues))
+	if err != nil {
+		if job.templateError != nil && len(job.templateError.SchemaErrors) > 0 {
+			return nil, true
+		}
+		return &fuzzFailure{check: "render", message: err.Error()}, false
+	}
+
+	for _, file := range sortedKeys(outputOfFiles) {
+		if ext := filepath.Ext(file); ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+		manifests, err := parseYamlFile(outputOfFiles[file])
+		if err != nil {
+			return &fuzzFailure{check: "yaml", message: fmt.Sprintf("%s: %s", file, err)}, false
+		}
+		for idx, manifest := range manifests {
+			if missing := missingManifestField(manifest); missing != "" {
+				return &fuzzFailure{check: "fields", message: fmt.Sprintf("%s: document %d has no %s", file, idx, missing)}, false
+			}
+		}
+	}
+
+	for _, suite := range f.suites {
+		for _, test := range suite.Tests {
+			if test == nil || test.Skip.Reason != "" {
+				continue
+			}
+			if failure, isInvalid := f.checkTest(suite, test, values); failure != nil || isInvalid {
+				return failure, isInvalid
+			}
+		}
+	}
+	return nil, false
+}
+
+// checkTest runs the test with the values, the values of the test override the generated values.
+func (f *chartFuzzer) checkTest(suite *TestSuite, test *TestJob, values map[string]interface{}) (*fuzzFailure, bool) {
+	chart, err := loadChartSilently(f.chartPath)
+	if err != nil {
+		return &fuzzFailure{check: "load", message: err.Error()}, false
+	}
+	test.fuzzValues = values
+	test.WithConfig(*NewTestConfig(chart, &snapshot.Cache{},
+		WithChartPath(f.chartPath),
+		WithPostRendererConfig(suite.PostRendererConfig),
+		WithDocumentSelector(test.DocumentSelector),
+	))
+	result := test.RunV3(&results.TestJobResult{})
+	if result.Passed {
+		return nil, false
+	}
+	if test.templateError != nil && len(test.templateError.SchemaErrors) > 0 {
+		return nil, true
+	}
+
+	failure := &fuzzFailure{check: fmt.Sprintf("%s / %s", suite.Name, test.Name)}
+	if result.ExecError != nil {
+		failure.message = result.ExecError.Error()
+		return failure, false
+	}
+	for _, assertion := range result.AssertsResult {
+		if !assertion.Passed {
+			failure.message = fmt.Sprintf("- asserts[%d] `%s` fail\n%s", assertion.Index, assertion.AssertType, strings.Join(assertion.FailInfo, "\n"))
+			break
+		}
+	}
+	return failure, false
+}
+
+// minimize removes the keys and items of the values, and simplifies the values, as long as the same check fails.
+func (f *chartFuzzer) minimize(values map[string]interface{}, failure *fuzzFailure) map[string]interface{} {
+	attempts := 0
+	for shrunk := true; shrunk && attempts < maxShrinkAttempts; {
+		shrunk = false
+		for _, variant := range shrinkValues(values) {
+			attempts++
+			candidate := variant.(map[string]interface{})
+			if candidateFailure, _ := f.check(candidate); candidateFailure != nil && candidateFailure.check == failure.check {
+				values = candidate
+				*failure = *candidateFailure
+				shrunk = true
+				break
+			}
+			if attempts >= maxShrinkAttempts {
+				break
+			}
+		}
+	}
+	return values
+}
+
+// loadChartSilently loads a fresh copy of the chart without logging, as the render modifies the chart.
+func loadChartSilently(chartPath string) (*v3chart.Chart, error) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stdout)
+	return v3loader.Load(chartPath)
+}
+
+// missingManifestField returns the first missing field of apiVersion, kind and metadata.name of the document.
+func missingManifestField(manifest common.K8sManifest) string {
+	for _, field := range []string{"apiVersion", "kind"} {
+		if value, ok := manifest[field].(string); !ok || value == "" {
+			return field
+		}
+	}
+	// The nested mappings are decoded as the type of the document.
+	metadata, _ := manifest["metadata"].(common.K8sManifest)
+	if name, ok := metadata["name"].(string); !ok || name == "" {
+		return "metadata.name"
+	}
+	return ""
+}
+
+// writeFuzzFailure writes the values of the failed iteration to `<failures path>/<chart>-<seed>-<iteration>.yaml`,
+// with the failed check as comment, and returns the path of the file.
+func writeFuzzFailure(chartName string, iteration int, options FuzzOptions, failure *fuzzFailure, values map[string]interface{}) (string, error) {
+	if err := os.MkdirAll(options.FailuresPath, 0755); err != nil {
+		return "", err
+	}
+	content, err := common.YmlMarshall(values)
+	if err != nil {
+		return "", err
+	}
+	header := new(strings.Builder)
+	fmt.Fprintf(header, "# helm unittest fuzz --seed %d, iteration %d: %s\n", options.Seed, iteration, failure.check)
+	for _, line := range strings.Split(failure.message, "\n") {
+		header.WriteString(strings.TrimRight("# "+line, " ") + "\n")
+	}
+	file := filepath.Join(options.FailuresPath, fmt.Sprintf("%s-%d-%d.yaml", common.FileNameOf(chartName), options.Seed, iteration))
+	return file, os.WriteFile(file, []byte(header.String()+content), 0644)
+}
@@ -0,0 +1,173 @@
+package unittest_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest"
+)
+
+const fuzzSchema = `{
+  "type": "object",
+  "required": ["name"],
+  "properties": {
+    "name": {"type": "string", "pattern": "^[a-z]{3,8}$"},
+    "replicas": {"type": "integer", "minimum": 1, "maximum": 5},
+    "debug": {"type": "boolean"},
+    "labels": {"type": "object", "additionalProperties": {"type": "string"}},
+    "pullPolicy": {"enum": ["Always", "IfNotPresent"]}
+  }
+}`
+
+// writeFuzzChart writes a chart with the values schema and the template.
+func writeFuzzChart(t *testing.T, template string) string {
+	t.Helper()
+	chartPath := filepath.Join(t.TempDir(), "fuzz")
+	assert.NoError(t, os.MkdirAll(filepath.Join(chartPath, "templates"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "Chart.yaml"), []byte("apiVersion: v2\nname: fuzz\nversion: 0.1.0\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "values.yaml"), []byte("name: app\nreplicas: 1\ndebug: false\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "values.schema.json"), []byte(fuzzSchema), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "templates", "deployment.yaml"), []byte(template), 0644))
+	return chartPath
+}
+
+func TestV3RunnerFuzzPassed(t *testing.T) {
+	chartPath := writeFuzzChart(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Values.name }}
+  labels:
+    {{- range $key, $value := .Values.labels }}
+    {{ $key }}: {{ $value | quote }}
+    {{- end }}
+spec:
+  replicas: {{ .Values.replicas }}
+`)
+	failuresPath := filepath.Join(t.TempDir(), "failures")
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{Printer: printer.NewPrinter(buffer, nil), TestFiles: []string{"tests/*_test.yaml"}}
+	passed := runner.FuzzV3([]string{chartPath}, FuzzOptions{Seed: 1, Iterations: 20, FailuresPath: failuresPath})
+
+	assert.True(t, passed, buffer.String())
+	assert.Contains(t, buffer.String(), "Fuzz: 20 iterations with seed 1, 0 failed")
+	assert.NoDirExists(t, failuresPath)
+}
+
+func TestV3RunnerFuzzMinimizesFailedValues(t *testing.T) {
+	chartPath := writeFuzzChart(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  {{- if not .Values.debug }}
+  name: {{ .Values.name }}
+  {{- end }}
+spec:
+  replicas: {{ .Values.replicas }}
+`)
+	failuresPath := filepath.Join(t.TempDir(), "failures")
+	options := FuzzOptions{Seed: 7, Iterations: 10, FailuresPath: failuresPath}
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{Printer: printer.NewPrinter(buffer, nil), TestFiles: []string{"tests/*_test.yaml"}, Failfast: true}
+	passed := runner.FuzzV3([]string{chartPath}, options)
+
+	assert.False(t, passed)
+	assert.Contains(t, buffer.String(), ": fields")
+	assert.Contains(t, buffer.String(), "fuzz/templates/deployment.yaml: document 0 has no metadata.name")
+	files, err := filepath.Glob(filepath.Join(failuresPath, "fuzz-7-*.yaml"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	content, err := os.ReadFile(files[0])
+	assert.NoError(t, err)
+	// Only the value which breaks the template is kept, the name of the chart defaults meets the schema.
+	assert.Contains(t, string(content), "# helm unittest fuzz --seed 7, iteration ")
+	assert.Contains(t, string(content), "# fuzz/templates/deployment.yaml: document 0 has no metadata.name\n")
+	assert.True(t, strings.HasSuffix(string(content), "metadata.name\ndebug: true\n"), string(content))
+
+	// The same seed generates the same values.
+	repeatedBuffer := new(bytes.Buffer)
+	repeatedRunner := TestRunner{Printer: printer.NewPrinter(repeatedBuffer, nil), TestFiles: []string{"tests/*_test.yaml"}, Failfast: true}
+	repeatedRunner.FuzzV3([]string{chartPath}, options)
+	assert.Equal(t, buffer.String(), repeatedBuffer.String())
+}
+
+func TestV3RunnerFuzzRunsTheTestsOfFuzzSuites(t *testing.T) {
+	chartPath := writeFuzzChart(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Values.name }}
+spec:
+  replicas: {{ .Values.replicas }}
+`)
+	assert.NoError(t, os.MkdirAll(filepath.Join(chartPath, "tests"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "deployment_test.yaml"), []byte(`suite: deployment
+fuzz: true
+templates:
+  - deployment.yaml
+tests:
+  - it: should keep the name of the test
+    set:
+      name: fixed
+    asserts:
+      - equal:
+          path: metadata.name
+          value: fixed
+  - it: should run a single replica
+    asserts:
+      - equal:
+          path: spec.replicas
+          value: 1
+`), 0644))
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{Printer: printer.NewPrinter(buffer, nil), TestFiles: []string{"tests/*_test.yaml"}, Failfast: true}
+	passed := runner.FuzzV3([]string{chartPath}, FuzzOptions{Seed: 3, Iterations: 20, FailuresPath: filepath.Join(t.TempDir(), "failures")})
+
+	assert.False(t, passed)
+	assert.Contains(t, buffer.String(), ": deployment / should run a single replica")
+	assert.NotContains(t, buffer.String(), "should keep the name of the test")
+}
+
+func TestV3RunnerFuzzSkipsSnapshotAssertions(t *testing.T) {
+	chartPath := writeFuzzChart(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Values.name }}
+`)
+	assert.NoError(t, os.MkdirAll(filepath.Join(chartPath, "tests"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "deployment_test.yaml"), []byte(`suite: deployment
+fuzz: true
+templates:
+  - deployment.yaml
+tests:
+  - it: should match the snapshot
+    asserts:
+      - matchSnapshot: {}
+      - matchSnapshotRaw: {}
+      - isKind:
+          of: Deployment
+`), 0644))
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{Printer: printer.NewPrinter(buffer, nil), TestFiles: []string{"tests/*_test.yaml"}}
+	passed := runner.FuzzV3([]string{chartPath}, FuzzOptions{Seed: 1, Iterations: 5, FailuresPath: filepath.Join(t.TempDir(), "failures")})
+
+	assert.True(t, passed)
+	assert.Contains(t, buffer.String(), "Skipped 2 snapshot assertions")
+	assert.NoDirExists(t, filepath.Join(chartPath, "tests", "__snapshot__"))
+}
+
+func TestV3RunnerFuzzWithoutSchema(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{Printer: printer.NewPrinter(buffer, nil), TestFiles: []string{"tests/*_test.yaml"}}
+	passed := runner.FuzzV3([]string{testV3BasicChart}, FuzzOptions{Iterations: 1, FailuresPath: filepath.Join(t.TempDir(), "failures")})
+
+	assert.True(t, passed)
+	assert.Contains(t, buffer.String(), "No values.schema.json")
+}
@@ -0,0 +1,573 @@
+package unittest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+const (
+	// maxGeneratedDepth stops the generation of recursive schemas
+	maxGeneratedDepth = 10
+	// maxGeneratedItems the number of items or keys generated above the minimum of the schema
+	maxGeneratedItems = 3
+	// maxGeneratedLength the length of the generated strings above the minimum of the schema
+	maxGeneratedLength = 12
+	// maxPatternRepeat the number of repetitions of unbounded repeats of patterns
+	maxPatternRepeat = 5
+	// generatedAlphabet the characters of the generated strings without pattern
+	generatedAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+// interestingStrings the strings which break templates that do not quote or validate the values
+var interestingStrings = []string{"", " ", "true", "null", "0", "1.0", "a: b", "- a", "#a", "a\"b", "a'b", "{{ a }}", "a\nb", "ä"}
+
+// interestingNumbers the numbers which break templates that do not format or validate the values
+var interestingNumbers = []float64{0, 1, -1, 65535, 65536, 1e6, -2147483648}
+
+// valuesGenerator generates random values which meet a values.schema.json, the same seed generates the same values.
+type valuesGenerator struct {
+	root map[string]interface{}
+	rng  *rand.Rand
+}
+
+// newValuesGenerator returns the generator of the values of the JSON schema.
+func newValuesGenerator(schemaJSON []byte, seed int64) (*valuesGenerator, error) {
+	root := map[string]interface{}{}
+	if err := json.Unmarshal(schemaJSON, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse values.schema.json: %w", err)
+	}
+	return &valuesGenerator{root: root, rng: rand.New(rand.NewSource(seed))}, nil
+}
+
+// generateValues returns the next random values of the schema, the values of the chart are always an object.
+func (g *valuesGenerator) generateValues() map[string]interface{} {
+	value, _ := g.generate(g.root, 0)
+	values, ok := value.(map[string]interface{})
+	if !ok {
+		return map[string]interface{}{}
+	}
+	return values
+}
+
+// generate returns a random value of the schema, false when no value can be generated,
+// like for unresolved references.
+func (g *valuesGenerator) generate(schema map[string]interface{}, depth int) (interface{}, bool) {
+	if depth > maxGeneratedDepth {
+		return nil, false
+	}
+	schema, ok := g.resolve(schema)
+	if !ok {
+		return nil, false
+	}
+
+	if value, found := schema["const"]; found {
+		return value, true
+	}
+	if enum, found := schema["enum"].([]interface{}); found && len(enum) > 0 {
+		return enum[g.rng.Intn(len(enum))], true
+	}
+	for _, keyword := range []string{"oneOf", "anyOf"} {
+		if branches := schemaList(schema[keyword]); len(branches) > 0 {
+			branch := branches[g.rng.Intn(len(branches))]
+			return g.generate(mergeSchemas(withoutKeyword(schema, keyword), branch), depth+1)
+		}
+	}
+
+	switch g.schemaType(schema) {
+	case "object":
+		return g.generateObject(schema, depth), true
+	case "array":
+		return g.generateArray(schema, depth), true
+	case "string":
+		return g.generateString(schema), true
+	case "integer":
+		return int64(g.generateNumber(schema, true)), true
+	case "number":
+		return g.generateNumber(schema, false), true
+	case "boolean":
+		return g.rng.Intn(2) == 0, true
+	case "null":
+		return nil, true
+	}
+	return nil, false
+}
+
+// resolve returns the schema of the local reference and the merged schemas of allOf.
+func (g *valuesGenerator) resolve(schema map[string]interface{}) (map[string]interface{}, bool) {
+	for range maxGeneratedDepth {
+		ref, found := schema["$ref"].(string)
+		if !found {
+			break
+		}
+		target, ok := g.lookupRef(ref)
+		if !ok {
+			return nil, false
+		}
+		schema = mergeSchemas(withoutKeyword(schema, "$ref"), target)
+	}
+	if _, found := schema["$ref"]; found {
+		return nil, false
+	}
+
+	if parts := schemaList(schema["allOf"]); len(parts) > 0 {
+		merged := withoutKeyword(schema, "allOf")
+		for _, part := range parts {
+			resolved, ok := g.resolve(part)
+			if !ok {
+				return nil, false
+			}
+			merged = mergeSchemas(merged, resolved)
+		}
+		return merged, true
+	}
+	return schema, true
+}
+
+// lookupRef returns the schema of a reference within the schema, like `#/definitions/image`.
+func (g *valuesGenerator) lookupRef(ref string) (map[string]interface{}, bool) {
+	pointer, found := strings.CutPrefix(ref, "#")
+	if !found {
+		return nil, false
+	}
+	current := g.root
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		next, ok := current[token].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+	return current, true
+}
+
+// schemaType returns the type of the schema, a random type of the types, or the type of the keywords without type.
+func (g *valuesGenerator) schemaType(schema map[string]interface{}) string {
+	switch typed := schema["type"].(type) {
+	case string:
+		return typed
+	case []interface{}:
+		if len(typed) > 0 {
+			if name, ok := typed[g.rng.Intn(len(typed))].(string); ok {
+				return name
+			}
+		}
+	}
+	for _, keyword := range []string{"properties", "additionalProperties", "required", "patternProperties"} {
+		if _, found := schema[keyword]; found {
+			return "object"
+		}
+	}
+	if _, found := schema["items"]; found {
+		return "array"
+	}
+	for _, keyword := range []string{"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf"} {
+		if _, found := schema[keyword]; found {
+			return "number"
+		}
+	}
+	for _, keyword := range []string{"pattern", "minLength", "maxLength", "format"} {
+		if _, found := schema[keyword]; found {
+			return "string"
+		}
+	}
+	return []string{"string", "integer", "boolean"}[g.rng.Intn(3)]
+}
+
+// generateObject returns the required properties and a random selection of the optional properties.
+func (g *valuesGenerator) generateObject(schema map[string]interface{}, depth int) map[string]interface{} {
+	object := map[string]interface{}{}
+	required := map[string]bool{}
+	if names, ok := schema["required"].([]interface{}); ok {
+		for _, name := range names {
+			if key, ok := name.(string); ok {
+				required[key] = true
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	for _, key := range sortedKeys(properties) {
+		property, ok := properties[key].(map[string]interface{})
+		if !ok || (!required[key] && g.rng.Intn(2) == 0) {
+			continue
+		}
+		if value, ok := g.generate(property, depth+1); ok {
+			object[key] = value
+		}
+	}
+
+	// Maps without properties, like labels and annotations, get a few generated keys.
+	if additional, ok := schema["additionalProperties"].(map[string]interface{}); ok && len(properties) == 0 {
+		for idx := range g.rng.Intn(maxGeneratedItems + 1) {
+			if value, ok := g.generate(additional, depth+1); ok {
+				object[fmt.Sprintf("key%d", idx)] = value
+			}
+		}
+	}
+	return object
+}
+
+// generateArray returns the items of the array within the minimum and maximum of items.
+func (g *valuesGenerator) generateArray(schema map[string]interface{}, depth int) []interface{} {
+	minItems := int(numberOr(schema, "minItems", 0))
+	maxItems := int(numberOr(schema, "maxItems", float64(minItems+maxGeneratedItems)))
+	maxItems = min(maxItems, minItems+maxGeneratedItems)
+	count := minItems
+	if maxItems > minItems {
+		count += g.rng.Intn(maxItems - minItems + 1)
+	}
+
+	unique, _ := schema["uniqueItems"].(bool)
+	tuple := schemaList(schema["items"])
+	itemSchema, _ := schema["items"].(map[string]interface{})
+	items := make([]interface{}, 0, count)
+	seen := map[string]bool{}
+	for idx := 0; len(items) < count && idx < count*maxGeneratedItems; idx++ {
+		current := itemSchema
+		if len(tuple) > 0 {
+			if len(items) >= len(tuple) {
+				break
+			}
+			current = tuple[len(items)]
+		}
+		if current == nil {
+			current = map[string]interface{}{}
+		}
+		item, ok := g.generate(current, depth+1)
+		if !ok {
+			break
+		}
+		key := fmt.Sprintf("%#v", item)
+		if unique && seen[key] {
+			continue
+		}
+		seen[key] = true
+		items = append(items, item)
+	}
+	return items
+}
+
+// generateString returns a string of the format or pattern of the schema, or a random or interesting string
+// within the length of the schema.
+func (g *valuesGenerator) generateString(schema map[string]interface{}) string {
+	minLength := int(numberOr(schema, "minLength", 0))
+	maxLength := int(numberOr(schema, "maxLength", math.MaxInt32))
+	fits := func(value string) bool {
+		length := len([]rune(value))
+		return length >= minLength && length <= maxLength
+	}
+
+	if pattern, ok := schema["pattern"].(string); ok {
+		for range maxPatternRepeat {
+			if value, ok := g.generatePattern(pattern); ok && fits(value) {
+				return value
+			}
+		}
+	}
+	if format, ok := schema["format"].(string); ok {
+		if value, ok := g.generateFormat(format); ok && fits(value) {
+			return value
+		}
+	}
+	if g.rng.Intn(3) == 0 {
+		value := interestingStrings[g.rng.Intn(len(interestingStrings))]
+		if fits(value) {
+			return value
+		}
+	}
+
+	length := minLength
+	if maxLength > minLength {
+		length += g.rng.Intn(min(maxLength, minLength+maxGeneratedLength) - minLength + 1)
+	}
+	value := make([]byte, length)
+	for idx := range value {
+		value[idx] = generatedAlphabet[g.rng.Intn(len(generatedAlphabet))]
+	}
+	return string(value)
+}
+
+// generateFormat returns a string of the format, false for unknown formats.
+func (g *valuesGenerator) generateFormat(format string) (string, bool) {
+	switch format {
+	case "date-time":
+		return fmt.Sprintf("20%02d-%02d-%02dT%02d:%02d:00Z", g.rng.Intn(100), g.rng.Intn(12)+1, g.rng.Intn(28)+1, g.rng.Intn(24), g.rng.Intn(60)), true
+	case "date":
+		return fmt.Sprintf("20%02d-%02d-%02d", g.rng.Intn(100), g.rng.Intn(12)+1, g.rng.Intn(28)+1), true
+	case "email":
+		return fmt.Sprintf("user%d@example.com", g.rng.Intn(1000)), true
+	case "hostname":
+		return fmt.Sprintf("host%d.example.com", g.rng.Intn(1000)), true
+	case "ipv4":
+		return fmt.Sprintf("%d.%d.%d.%d", g.rng.Intn(256), g.rng.Intn(256), g.rng.Intn(256), g.rng.Intn(256)), true
+	case "uri":
+		return fmt.Sprintf("https://example.com/%d", g.rng.Intn(1000)), true
+	case "uuid":
+		return fmt.Sprintf("%08x-%04x-4%03x-8%03x-%012x", g.rng.Uint32(), g.rng.Intn(1<<16), g.rng.Intn(1<<12), g.rng.Intn(1<<12), g.rng.Int63n(1<<48)), true
+	}
+	return "", false
+}
+
+// generatePattern returns a string matching the regular expression, false when the pattern is not supported.
+func (g *valuesGenerator) generatePattern(pattern string) (string, bool) {
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+	builder := new(strings.Builder)
+	if !g.writePattern(builder, parsed.Simplify()) {
+		return "", false
+	}
+	value := builder.String()
+	if matched, err := regexp.MatchString(pattern, value); err != nil || !matched {
+		return "", false
+	}
+	return value, true
+}
+
+// writePattern writes a random match of the regular expression to the builder.
+func (g *valuesGenerator) writePattern(builder *strings.Builder, re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return true
+	case syntax.OpLiteral:
+		builder.WriteString(string(re.Rune))
+		return true
+	case syntax.OpCharClass:
+		if len(re.Rune) < 2 {
+			return false
+		}
+		// Prefer the printable ASCII characters of the class.
+		ranges := make([][2]rune, 0, len(re.Rune)/2)
+		for idx := 0; idx+1 < len(re.Rune); idx += 2 {
+			low, high := max(re.Rune[idx], ' '), min(re.Rune[idx+1], '~')
+			if low <= high {
+				ranges = append(ranges, [2]rune{low, high})
+			}
+		}
+		if len(ranges) == 0 {
+			ranges = append(ranges, [2]rune{re.Rune[0], re.Rune[1]})
+		}
+		selected := ranges[g.rng.Intn(len(ranges))]
+		builder.WriteRune(selected[0] + rune(g.rng.Intn(int(selected[1]-selected[0])+1)))
+		return true
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		builder.WriteByte(generatedAlphabet[g.rng.Intn(len(generatedAlphabet))])
+		return true
+	case syntax.OpCapture:
+		return g.writePattern(builder, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if !g.writePattern(builder, sub) {
+				return false
+			}
+		}
+		return true
+	case syntax.OpAlternate:
+		return g.writePattern(builder, re.Sub[g.rng.Intn(len(re.Sub))])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		low, high := 0, maxPatternRepeat
+		switch re.Op {
+		case syntax.OpPlus:
+			low = 1
+		case syntax.OpQuest:
+			high = 1
+		case syntax.OpRepeat:
+			low, high = re.Min, re.Max
+			if high < 0 {
+				high = low + maxPatternRepeat
+			}
+		}
+		for range low + g.rng.Intn(high-low+1) {
+			if !g.writePattern(builder, re.Sub[0]) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// generateNumber returns a number within the minimum and maximum of the schema, a multiple of multipleOf.
+func (g *valuesGenerator) generateNumber(schema map[string]interface{}, integer bool) float64 {
+	low, high := -1000.0, 1000.0
+	lowBound, hasLow := number(schema["minimum"])
+	highBound, hasHigh := number(schema["maximum"])
+	step := 0.0
+	if integer {
+		step = 1
+	}
+	if exclusive, ok := number(schema["exclusiveMinimum"]); ok && (!hasLow || exclusive >= lowBound) {
+		lowBound, hasLow = exclusive+max(step, 0.001), true
+	}
+	if exclusive, ok := number(schema["exclusiveMaximum"]); ok && (!hasHigh || exclusive <= highBound) {
+		highBound, hasHigh = exclusive-max(step, 0.001), true
+	}
+	if hasLow {
+		low = lowBound
+		if !hasHigh {
+			high = low + 2000
+		}
+	}
+	if hasHigh {
+		high = highBound
+		if !hasLow {
+			low = high - 2000
+		}
+	}
+
+	value := low + g.rng.Float64()*(high-low)
+	if g.rng.Intn(3) == 0 {
+		interesting := interestingNumbers[g.rng.Intn(len(interestingNumbers))]
+		if interesting >= low && interesting <= high {
+			value = interesting
+		}
+	}
+	if multipleOf, ok := number(schema["multipleOf"]); ok && multipleOf > 0 {
+		lowMultiple, highMultiple := math.Ceil(low/multipleOf), math.Floor(high/multipleOf)
+		if highMultiple >= lowMultiple {
+			value = (lowMultiple + float64(g.rng.Int63n(int64(highMultiple-lowMultiple)+1))) * multipleOf
+		}
+	}
+	if integer {
+		value = math.Ceil(value)
+		if value > high {
+			value = math.Floor(high)
+		}
+	}
+	return value
+}
+
+// shrinkValues returns the smaller variants of the value, each with one removed key or item, or one simplified
+// value. The variants removing keys and items come first, to minimize the values as fast as possible.
+func shrinkValues(value interface{}) []interface{} {
+	variants := []interface{}{}
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		keys := sortedKeys(typed)
+		for _, key := range keys {
+			variant := copySet(typed)
+			delete(variant, key)
+			variants = append(variants, variant)
+		}
+		for _, key := range keys {
+			for _, shrunk := range shrinkValues(typed[key]) {
+				variant := copySet(typed)
+				variant[key] = shrunk
+				variants = append(variants, variant)
+			}
+		}
+	case []interface{}:
+		for idx := range typed {
+			variants = append(variants, append(append([]interface{}{}, typed[:idx]...), typed[idx+1:]...))
+		}
+		for idx, item := range typed {
+			for _, shrunk := range shrinkValues(item) {
+				variant := append([]interface{}{}, typed...)
+				variant[idx] = shrunk
+				variants = append(variants, variant)
+			}
+		}
+	case string:
+		if typed != "" {
+			variants = append(variants, "")
+		}
+	case int64:
+		if typed != 0 {
+			variants = append(variants, int64(0))
+		}
+	case float64:
+		if typed != 0 {
+			variants = append(variants, float64(0))
+		}
+	case bool:
+		if typed {
+			variants = append(variants, false)
+		}
+	}
+	return variants
+}
+
+// mergeSchemas returns the keywords of both schemas, the properties and required are combined.
+func mergeSchemas(base, other map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(other))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range other {
+		switch key {
+		case "properties":
+			properties := map[string]interface{}{}
+			if baseProperties, ok := merged[key].(map[string]interface{}); ok {
+				for name, property := range baseProperties {
+					properties[name] = property
+				}
+			}
+			if otherProperties, ok := value.(map[string]interface{}); ok {
+				for name, property := range otherProperties {
+					if baseProperty, ok := properties[name].(map[string]interface{}); ok {
+						if otherProperty, ok := property.(map[string]interface{}); ok {
+							property = mergeSchemas(baseProperty, otherProperty)
+						}
+					}
+					properties[name] = property
+				}
+			}
+			merged[key] = properties
+		case "required":
+			required, _ := merged[key].([]interface{})
+			others, _ := value.([]interface{})
+			merged[key] = append(append([]interface{}{}, required...), others...)
+		default:
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// withoutKeyword returns a copy of the schema without the keyword.
+func withoutKeyword(schema map[string]interface{}, keyword string) map[string]interface{} {
+	copied := make(map[string]interface{}, len(schema))
+	for key, value := range schema {
+		if key != keyword {
+			copied[key] = value
+		}
+	}
+	return copied
+}
+
+// schemaList returns the schemas of a list keyword, like oneOf.
+func schemaList(value interface{}) []map[string]interface{} {
+	list, _ := value.([]interface{})
+	schemas := make([]map[string]interface{}, 0, len(list))
+	for _, item := range list {
+		if schema, ok := item.(map[string]interface{}); ok {
+			schemas = append(schemas, schema)
+		}
+	}
+	return schemas
+}
+
+// number returns the number of a keyword of the schema.
+func number(value interface{}) (float64, bool) {
+	typed, ok := value.(float64)
+	return typed, ok
+}
+
+// numberOr returns the number of the keyword of the schema, or the fallback when it is not defined.
+func numberOr(schema map[string]interface{}, keyword string, fallback float64) float64 {
+	if value, ok := number(schema[keyword]); ok {
+		return value
+	}
+	return fallback
+}
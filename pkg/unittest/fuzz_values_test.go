@@ -0,0 +1,86 @@
+package unittest
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v3util "helm.sh/helm/v3/pkg/chartutil"
+)
+
+func TestValuesGeneratorMeetsSchema(t *testing.T) {
+	schema := []byte(`{
+  "type": "object",
+  "required": ["image", "ports"],
+  "definitions": {
+    "port": {"type": "integer", "minimum": 1, "maximum": 65535}
+  },
+  "properties": {
+    "image": {
+      "type": "object",
+      "required": ["repository", "pullPolicy"],
+      "properties": {
+        "repository": {"type": "string", "pattern": "^[a-z0-9-_]+$"},
+        "pullPolicy": {"type": "string", "pattern": "^(Always|Never|IfNotPresent)$"},
+        "tag": {"type": ["string", "null"], "maxLength": 5}
+      }
+    },
+    "ports": {"type": "array", "minItems": 1, "maxItems": 3, "uniqueItems": true, "items": {"$ref": "#/definitions/port"}},
+    "replicas": {"type": "integer", "exclusiveMinimum": 0, "multipleOf": 2},
+    "ratio": {"type": "number", "minimum": 0, "maximum": 1},
+    "email": {"type": "string", "format": "email"},
+    "mode": {"oneOf": [{"const": "simple"}, {"type": "object", "required": ["advanced"], "properties": {"advanced": {"type": "boolean"}}}]},
+    "resources": {"allOf": [{"properties": {"cpu": {"type": "string", "minLength": 1}}}, {"required": ["cpu"]}]}
+  }
+}`)
+	generator, err := newValuesGenerator(schema, 42)
+	assert.NoError(t, err)
+
+	for range 200 {
+		values := generator.generateValues()
+		assert.NoError(t, v3util.ValidateAgainstSingleSchema(values, schema), "%#v", values)
+	}
+}
+
+func TestValuesGeneratorIsReproducible(t *testing.T) {
+	schema := []byte(`{"type": "object", "properties": {"name": {"type": "string"}, "count": {"type": "integer"}, "enabled": {"type": "boolean"}}}`)
+	generator, err := newValuesGenerator(schema, 1)
+	assert.NoError(t, err)
+	repeated, err := newValuesGenerator(schema, 1)
+	assert.NoError(t, err)
+
+	for range 20 {
+		assert.Equal(t, generator.generateValues(), repeated.generateValues())
+	}
+}
+
+func TestValuesGeneratorWithInvalidSchema(t *testing.T) {
+	_, err := newValuesGenerator([]byte(`{"type": `), 0)
+	assert.ErrorContains(t, err, "failed to parse values.schema.json")
+}
+
+func TestValuesGeneratorPattern(t *testing.T) {
+	generator, err := newValuesGenerator([]byte(`{}`), 0)
+	assert.NoError(t, err)
+
+	for _, pattern := range []string{`^[a-z]{3,8}$`, `^(Always|Never)$`, `\d+\.\d+`, `^v?[0-9]+(-[a-z]+)?$`, `^.{2}$`} {
+		value, ok := generator.generatePattern(pattern)
+		assert.True(t, ok, pattern)
+		assert.Regexp(t, regexp.MustCompile(pattern), value)
+	}
+}
+
+func TestShrinkValues(t *testing.T) {
+	variants := shrinkValues(map[string]interface{}{
+		"enabled": true,
+		"items":   []interface{}{"a"},
+	})
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"items": []interface{}{"a"}},
+		map[string]interface{}{"enabled": true},
+		map[string]interface{}{"enabled": false, "items": []interface{}{"a"}},
+		map[string]interface{}{"enabled": true, "items": []interface{}{}},
+		map[string]interface{}{"enabled": true, "items": []interface{}{""}},
+	}, variants)
+}
@@ -0,0 +1,287 @@
+package unittest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	"gopkg.in/yaml.v3"
+)
+
+// inlineSnapshotPosition the position of a matchInlineSnapshot assertion in the test suite
+type inlineSnapshotPosition struct {
+	test      int
+	assertion int
+}
+
+// inlineSnapshotComparer compares the inline snapshot of an assertion, and collects the content to write
+// to the test suite file when the inline snapshot is missing or updated.
+type inlineSnapshotComparer struct {
+	cache    *snapshot.Cache
+	position inlineSnapshotPosition
+	// updates the inline snapshots to write of the suite, nil when the test suite file can not be written
+	updates  map[inlineSnapshotPosition]string
+	compared bool
+}
+
+// CompareToInlineSnapshot implement validators.InlineSnapshotComparer
+func (c *inlineSnapshotComparer) CompareToInlineSnapshot(expected, actual string) *snapshot.CompareResult {
+	result := &snapshot.CompareResult{CachedSnapshot: expected, NewSnapshot: actual}
+	if c.compared {
+		result.Msg = " inline snapshot of a single template, set the template of the assertion"
+		return result
+	}
+	c.compared = true
+
+	if expected == actual {
+		result.Passed = true
+		return result
+	}
+
+	if expected == "" && c.cache.IsCI && !c.cache.IsUpdating {
+		result.Msg = " inline snapshot to be stored, new snapshots are not stored in CI mode"
+		return result
+	}
+	if expected == "" || c.cache.IsUpdating {
+		if c.updates == nil {
+			result.Msg = " inline snapshot to be stored, inline snapshots of rendered test suites are not stored"
+			return result
+		}
+		c.updates[c.position] = actual
+		result.Passed = true
+		return result
+	}
+
+	if c.cache.IsStructuralDiff {
+		if changes, err := snapshot.StructuralDiff(expected, actual); err == nil {
+			result.Diff = strings.Join(changes, "\n")
+		}
+	}
+	return result
+}
+
+// storeInlineSnapshots writes the missing and updated inline snapshots to the test suite file.
+func (s *TestSuite) storeInlineSnapshots() error {
+	if len(s.inlineSnapshots) == 0 {
+		return nil
+	}
+
+	content, err := os.ReadFile(s.definitionFile)
+	if err != nil {
+		return err
+	}
+	updated, err := updateInlineSnapshots(string(content), s.documentIndex, s.inlineSnapshots)
+	if err != nil {
+		return fmt.Errorf("%s: %w", s.definitionFile, err)
+	}
+	if err := os.WriteFile(s.definitionFile, []byte(updated), 0644); err != nil {
+		return err
+	}
+	clear(s.inlineSnapshots)
+	return nil
+}
+
+// updateInlineSnapshots replaces the content of the matchInlineSnapshot assertions in the document of the
+// test suite file. The assertions are located with the yaml nodes, and only the lines of the content are
+// replaced, to keep the comments and formatting of the rest of the file.
+func updateInlineSnapshots(content string, documentIndex int, snapshots map[inlineSnapshotPosition]string) (string, error) {
+	document, err := suiteDocument(content, documentIndex)
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(content, "\n")
+	edits := make([]lineEdit, 0, len(snapshots))
+	for position, snapshotContent := range snapshots {
+		key, assertion, err := inlineSnapshotNode(document, position)
+		if err != nil {
+			return "", err
+		}
+		edit, err := inlineSnapshotEdit(lines, key, assertion, snapshotContent)
+		if err != nil {
+			return "", fmt.Errorf("tests[%d].asserts[%d]: %w", position.test, position.assertion, err)
+		}
+		edits = append(edits, edit)
+	}
+
+	// Apply the edits from the end of the file, so the lines of the other edits are unchanged. An insert
+	// before a replaced line shares the start of the replacement, and is applied after the replacement.
+	sort.Slice(edits, func(i, j int) bool {
+		if edits[i].start != edits[j].start {
+			return edits[i].start > edits[j].start
+		}
+		return edits[i].end > edits[j].end
+	})
+	for _, edit := range edits {
+		lines = append(lines[:edit.start], append(edit.lines, lines[edit.end:]...)...)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// lineEdit replaces the lines from start up to end (exclusive), both zero based
+type lineEdit struct {
+	start int
+	end   int
+	lines []string
+}
+
+// suiteDocument returns the mapping of the test suite with the index in the file.
+func suiteDocument(content string, documentIndex int) (*yaml.Node, error) {
+	decoder := yaml.NewDecoder(strings.NewReader(content))
+	index := 0
+	for {
+		var document yaml.Node
+		if err := decoder.Decode(&document); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("test suite %d not found", documentIndex)
+			}
+			return nil, err
+		}
+		if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+			continue
+		}
+		if index == documentIndex {
+			return document.Content[0], nil
+		}
+		index++
+	}
+}
+
+// inlineSnapshotNode returns the key and the value of the matchInlineSnapshot assertion at the position.
+func inlineSnapshotNode(document *yaml.Node, position inlineSnapshotPosition) (*yaml.Node, *yaml.Node, error) {
+	_, tests := mappingEntry(document, "tests")
+	if tests == nil || tests.Kind != yaml.SequenceNode || position.test >= len(tests.Content) {
+		return nil, nil, fmt.Errorf("tests[%d] not found", position.test)
+	}
+	_, asserts := mappingEntry(tests.Content[position.test], "asserts")
+	if asserts == nil || asserts.Kind != yaml.SequenceNode || position.assertion >= len(asserts.Content) {
+		return nil, nil, fmt.Errorf("tests[%d].asserts[%d] not found", position.test, position.assertion)
+	}
+	key, assertion := mappingEntry(asserts.Content[position.assertion], "matchInlineSnapshot")
+	if assertion == nil {
+		return nil, nil, fmt.Errorf("tests[%d].asserts[%d].matchInlineSnapshot not found", position.test, position.assertion)
+	}
+	return key, assertion, nil
+}
+
+// mappingEntry returns the key and the value of the key in the mapping, nil when not found.
+func mappingEntry(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for idx := 0; idx+1 < len(node.Content); idx += 2 {
+		if node.Content[idx].Value == key {
+			return node.Content[idx], node.Content[idx+1]
+		}
+	}
+	return nil, nil
+}
+
+// inlineSnapshotEdit returns the edit to write the content in the assertion mapping,
+// replacing the existing content or appending the content to the mapping.
+func inlineSnapshotEdit(lines []string, key, assertion *yaml.Node, content string) (lineEdit, error) {
+	if assertion.Kind == yaml.ScalarNode && assertion.Tag == "!!null" {
+		// matchInlineSnapshot without fields, the content is added as the only field.
+		return flowMappingEdit(lines, key, &yaml.Node{Kind: yaml.MappingNode}, content)
+	}
+	if assertion.Kind != yaml.MappingNode {
+		return lineEdit{}, fmt.Errorf("matchInlineSnapshot is no mapping")
+	}
+	if assertion.Style&yaml.FlowStyle != 0 {
+		return flowMappingEdit(lines, key, assertion, content)
+	}
+
+	indent := assertion.Content[0].Column - 1
+	for idx := 0; idx+1 < len(assertion.Content); idx += 2 {
+		if contentKey := assertion.Content[idx]; contentKey.Value == "content" {
+			start := contentKey.Line - 1
+			return lineEdit{start: start, end: blockEnd(lines, start, indent), lines: contentLines(indent, content)}, nil
+		}
+	}
+	lastKey := assertion.Content[len(assertion.Content)-2]
+	end := blockEnd(lines, lastKey.Line-1, indent)
+	return lineEdit{start: end, end: end, lines: contentLines(indent, content)}, nil
+}
+
+// flowMappingEdit rewrites an empty value or a flow mapping on the line of the key,
+// like `matchInlineSnapshot: {path: spec}`, as a block mapping including the content.
+func flowMappingEdit(lines []string, key, assertion *yaml.Node, content string) (lineEdit, error) {
+	start := key.Line - 1
+	line := lines[start]
+	multiLine := assertion.Line != 0 && assertion.Line != key.Line
+	if multiLine || strings.Count(line, "{") > 1 || strings.Count(line, "{") != strings.Count(line, "}") {
+		return lineEdit{}, fmt.Errorf("matchInlineSnapshot is a nested or multi-line flow mapping, use a block mapping instead")
+	}
+
+	indent := key.Column - 1 + 2
+	keyEnd := key.Column - 1 + len(key.Value)
+	block := []string{line[:keyEnd] + ":"}
+	for idx := 0; idx+1 < len(assertion.Content); idx += 2 {
+		if assertion.Content[idx].Value == "content" {
+			continue
+		}
+		entry := &yaml.Node{Kind: yaml.MappingNode, Content: assertion.Content[idx : idx+2]}
+		encoded, err := yaml.Marshal(entry)
+		if err != nil {
+			return lineEdit{}, err
+		}
+		for _, encodedLine := range strings.Split(strings.TrimSuffix(string(encoded), "\n"), "\n") {
+			block = append(block, strings.Repeat(" ", indent)+encodedLine)
+		}
+	}
+	block = append(block, contentLines(indent, content)...)
+	// Keep the comment after the value on the line of the key.
+	rest := line[keyEnd+1:]
+	if end := strings.LastIndex(rest, "}"); end >= 0 {
+		rest = rest[end+1:]
+	}
+	if comment := strings.TrimSpace(rest); strings.HasPrefix(comment, "#") {
+		block[0] += " " + comment
+	}
+	return lineEdit{start: start, end: start + 1, lines: block}, nil
+}
+
+// blockEnd returns the line after the value of the key on line start, the value ends at the first line
+// which is not indented more than the key. Trailing empty lines are not part of the value.
+func blockEnd(lines []string, start, indent int) int {
+	end := start + 1
+	for idx := start + 1; idx < len(lines); idx++ {
+		trimmed := strings.TrimLeft(lines[idx], " ")
+		if trimmed == "" {
+			continue
+		}
+		if len(lines[idx])-len(trimmed) <= indent {
+			break
+		}
+		end = idx + 1
+	}
+	return end
+}
+
+// contentLines returns the content field as a literal block scalar.
+func contentLines(indent int, content string) []string {
+	prefix := strings.Repeat(" ", indent)
+	indicator := "|"
+	if !strings.HasSuffix(content, "\n") {
+		indicator = "|-"
+	} else if strings.HasSuffix(content, "\n\n") {
+		indicator = "|+"
+	}
+	if strings.HasPrefix(content, " ") || strings.HasPrefix(content, "\n") {
+		indicator = "|2" + strings.TrimPrefix(indicator, "|")
+	}
+
+	result := []string{prefix + "content: " + indicator}
+	for _, line := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+		if line == "" {
+			result = append(result, "")
+			continue
+		}
+		result = append(result, prefix+"  "+line)
+	}
+	return result
+}
@@ -0,0 +1,117 @@
+package unittest_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	"github.com/stretchr/testify/assert"
+)
+
+const inlineSnapshotSuite = `suite: inline snapshots
+templates:
+  - templates/network.yaml
+tests:
+  - it: should store the inline snapshots
+    asserts:
+      # missing content
+      - matchInlineSnapshot:
+          path: metadata.labels.app
+      - matchInlineSnapshot: {path: kind} # flow mapping
+      - matchInlineSnapshot:
+      - matchInlineSnapshot:
+          path: metadata.labels
+          content: |
+            app: other-cluster
+`
+
+func writeInlineSnapshotSuite(t *testing.T) (string, string) {
+	chartPath := filepath.Join(t.TempDir(), "chart-snapshot")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS("testdata/chart-snapshot")))
+	suiteFile := filepath.Join(chartPath, "tests", "inline", "store_test.yaml")
+	assert.NoError(t, os.WriteFile(suiteFile, []byte(inlineSnapshotSuite), 0644))
+	return chartPath, suiteFile
+}
+
+func TestV3RunnerWith_Fixture_Chart_WithInlineSnapshot(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/inline/*_test.yaml"},
+		Strict:    true,
+	}
+	assert.True(t, runner.RunV3([]string{"testdata/chart-snapshot"}), buffer.String())
+}
+
+func TestV3RunnerWith_Fixture_Chart_WithInlineSnapshot_Store(t *testing.T) {
+	chartPath, suiteFile := writeInlineSnapshotSuite(t)
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/inline/store_test.yaml"},
+	}
+
+	// The missing inline snapshots are stored, the outdated inline snapshot fails.
+	assert.False(t, runner.RunV3([]string{chartPath}))
+	stored, err := os.ReadFile(suiteFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(stored), "          path: metadata.labels.app\n          content: |\n            test-cluster\n")
+	assert.Contains(t, string(stored), "          content: |\n            app: other-cluster\n")
+
+	runner.UpdateSnapshot = true
+	assert.True(t, runner.RunV3([]string{chartPath}))
+	stored, err = os.ReadFile(suiteFile)
+	assert.NoError(t, err)
+	assert.Equal(t, `suite: inline snapshots
+templates:
+  - templates/network.yaml
+tests:
+  - it: should store the inline snapshots
+    asserts:
+      # missing content
+      - matchInlineSnapshot:
+          path: metadata.labels.app
+          content: |
+            test-cluster
+      - matchInlineSnapshot: # flow mapping
+          path: kind
+          content: |
+            NetworkPolicy
+      - matchInlineSnapshot:
+          content: |
+            apiVersion: networking.k8s.io/v1
+            kind: NetworkPolicy
+            metadata:
+              labels:
+                app: test-cluster
+                app.kubernetes.io/version: null
+      - matchInlineSnapshot:
+          path: metadata.labels
+          content: |
+            app: test-cluster
+            app.kubernetes.io/version: null
+`, string(stored))
+
+	// The stored inline snapshots match without updating.
+	runner.UpdateSnapshot = false
+	assert.True(t, runner.RunV3([]string{chartPath}))
+}
+
+func TestV3RunnerWith_Fixture_Chart_WithInlineSnapshot_CI(t *testing.T) {
+	chartPath, suiteFile := writeInlineSnapshotSuite(t)
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/inline/store_test.yaml"},
+		CI:        true,
+	}
+
+	assert.False(t, runner.RunV3([]string{chartPath}))
+	assert.Contains(t, buffer.String(), "Expected inline snapshot to be stored, new snapshots are not stored in CI mode")
+	stored, err := os.ReadFile(suiteFile)
+	assert.NoError(t, err)
+	assert.Equal(t, inlineSnapshotSuite, string(stored))
+}
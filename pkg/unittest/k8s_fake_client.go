@@ -1,15 +1,59 @@
 package unittest
 
 import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
 	"path"
+	"path/filepath"
+	"slices"
+	"strings"
 
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	v3chart "helm.sh/helm/v3/pkg/chart"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
 )
 
+// clusterScopedKinds the kinds of the built-in types which are not namespaced.
+var clusterScopedKinds = []string{
+	"APIService",
+	"CertificateSigningRequest",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"ComponentStatus",
+	"CSIDriver",
+	"CSINode",
+	"CustomResourceDefinition",
+	"FlowSchema",
+	"IngressClass",
+	"MutatingWebhookConfiguration",
+	"Namespace",
+	"Node",
+	"PersistentVolume",
+	"PodSecurityPolicy",
+	"PriorityClass",
+	"PriorityLevelConfiguration",
+	"RuntimeClass",
+	"StorageClass",
+	"ValidatingAdmissionPolicy",
+	"ValidatingAdmissionPolicyBinding",
+	"ValidatingWebhookConfiguration",
+	"VolumeAttachment",
+}
+
+// irregularResources the resources of the built-in kinds which can not be guessed from the kind.
+var irregularResources = map[string]string{
+	"Endpoints": "endpoints",
+}
+
 type KubernetesFakeKindProps struct {
 	ShouldErr  error                       `yaml:"should_err"`
 	Gvr        schema.GroupVersionResource `yaml:"gvr"`
@@ -19,6 +63,113 @@ type KubernetesFakeKindProps struct {
 type KubernetesFakeClientProvider struct {
 	Scheme  map[string]KubernetesFakeKindProps `yaml:"scheme"`
 	Objects []map[string]interface{}           `yaml:"objects"`
+	// yaml files or directories containing objects, relative to the test suite file
+	Files []string `yaml:"files"`
+
+	mapper meta.RESTMapper
+	client *fake.FakeDynamicClient
+}
+
+// enabled returns whether objects are defined to fake.
+func (p *KubernetesFakeClientProvider) enabled() bool {
+	return len(p.Objects) > 0 || len(p.Files) > 0
+}
+
+// prepare builds the fake client with all objects once per render, the resources of the objects are
+// derived from the built-in types, the custom resource definitions of the chart and the scheme.
+func (p *KubernetesFakeClientProvider) prepare(chart *v3chart.Chart, baseDir string) error {
+	objects := slices.Clone(p.Objects)
+	for _, file := range p.Files {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(baseDir, file)
+		}
+		fileObjects, err := loadObjects(file)
+		if err != nil {
+			return fmt.Errorf("kubernetesProvider.files: %w", err)
+		}
+		objects = append(objects, fileObjects...)
+	}
+
+	mapper := newDefaultRESTMapper()
+	if chart != nil {
+		for _, crd := range chart.CRDObjects() {
+			manifests, err := parseYamlFile(string(crd.File.Data))
+			if err != nil {
+				return fmt.Errorf("kubernetesProvider: crd %s: %w", crd.Filename, err)
+			}
+			addCustomResourceDefinitions(mapper, manifests)
+		}
+	}
+	manifests := make([]common.K8sManifest, len(objects))
+	for idx, object := range objects {
+		manifests[idx] = object
+	}
+	addCustomResourceDefinitions(mapper, manifests)
+	p.mapper = mapper
+
+	listKinds := map[schema.GroupVersionResource]string{}
+	for gvk := range scheme.Scheme.AllKnownTypes() {
+		if strings.HasSuffix(gvk.Kind, "List") || gvk.Version == runtime.APIVersionInternal {
+			continue
+		}
+		if gvr, _ := p.resourceFor(gvk); gvr.Resource != "" {
+			listKinds[gvr] = gvk.Kind + "List"
+		}
+	}
+	unstructuredObjects := make([]*unstructured.Unstructured, 0, len(objects))
+	for _, object := range objects {
+		// Copy the object with json types, which leaves the test definition untouched and can be deep copied by the client.
+		content, err := json.Marshal(object)
+		if err != nil {
+			return fmt.Errorf("kubernetesProvider: %w", err)
+		}
+		item := &unstructured.Unstructured{}
+		if err := item.UnmarshalJSON(content); err != nil {
+			return fmt.Errorf("kubernetesProvider: %w", err)
+		}
+		gvr, _ := p.resourceFor(item.GroupVersionKind())
+		listKinds[gvr] = item.GetKind() + "List"
+		unstructuredObjects = append(unstructuredObjects, item)
+	}
+	for _, props := range p.Scheme {
+		if props.Gvr.Resource != "" {
+			if _, ok := listKinds[props.Gvr]; !ok {
+				listKinds[props.Gvr] = "List"
+			}
+		}
+	}
+
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+	for _, item := range unstructuredObjects {
+		gvr, namespaced := p.resourceFor(item.GroupVersionKind())
+		// Store the objects like the api-server, namespaced objects always have a namespace.
+		if !namespaced {
+			item.SetNamespace("")
+		} else if item.GetNamespace() == "" {
+			item.SetNamespace("default")
+		}
+		if err := client.Tracker().Create(gvr, item, item.GetNamespace()); err != nil {
+			return fmt.Errorf("kubernetesProvider: %w", err)
+		}
+	}
+	p.client = client
+	return nil
+}
+
+// resourceFor returns the resource of the kind, the scheme takes precedence over the mapped resources.
+// Unknown kinds are guessed, like the resources of the fake client.
+func (p *KubernetesFakeClientProvider) resourceFor(gvk schema.GroupVersionKind) (schema.GroupVersionResource, bool) {
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+	if props, ok := p.Scheme[path.Join(apiVersion, kind)]; ok && props.Gvr.Resource != "" {
+		return props.Gvr, props.Namespaced
+	}
+	if p.mapper != nil {
+		if mapping, err := p.mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err == nil {
+			return mapping.Resource, mapping.Scope.Name() == meta.RESTScopeNameNamespace
+		}
+	}
+	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+	return gvr, true
 }
 
 func (p *KubernetesFakeClientProvider) GetClientFor(apiVersion, kind string) (dynamic.NamespaceableResourceInterface, bool, error) {
@@ -27,15 +178,105 @@ func (p *KubernetesFakeClientProvider) GetClientFor(apiVersion, kind string) (dy
 		return nil, false, props.ShouldErr
 	}
 
-	return fake.NewSimpleDynamicClient(runtime.NewScheme(), convertRuntimeObject(p.Objects)...).Resource(props.Gvr), props.Namespaced, nil
+	if p.client == nil {
+		if err := p.prepare(nil, ""); err != nil {
+			return nil, false, err
+		}
+	}
+
+	gvr, namespaced := p.resourceFor(schema.FromAPIVersionAndKind(apiVersion, kind))
+	return p.client.Resource(gvr), namespaced, nil
 }
 
-func convertRuntimeObject(input []map[string]interface{}) []runtime.Object {
-	result := make([]runtime.Object, len(input))
+// newDefaultRESTMapper returns a RESTMapper of the built-in types known by client-go.
+func newDefaultRESTMapper() *meta.DefaultRESTMapper {
+	mapper := meta.NewDefaultRESTMapper(scheme.Scheme.PrioritizedVersionsAllGroups())
+	for gvk := range scheme.Scheme.AllKnownTypes() {
+		if strings.HasSuffix(gvk.Kind, "List") || gvk.Version == runtime.APIVersionInternal {
+			continue
+		}
+		scope := meta.RESTScopeNamespace
+		if slices.Contains(clusterScopedKinds, gvk.Kind) {
+			scope = meta.RESTScopeRoot
+		}
+		if resource, ok := irregularResources[gvk.Kind]; ok {
+			mapper.AddSpecific(gvk, gvk.GroupVersion().WithResource(resource), gvk.GroupVersion().WithResource(resource), scope)
+			continue
+		}
+		mapper.Add(gvk, scope)
+	}
+	return mapper
+}
+
+// addCustomResourceDefinitions adds the resources of the custom resource definitions to the mapper.
+func addCustomResourceDefinitions(mapper *meta.DefaultRESTMapper, manifests []common.K8sManifest) {
+	for _, manifest := range manifests {
+		if manifest["kind"] != "CustomResourceDefinition" {
+			continue
+		}
+		group, _ := common.NestedString(manifest, "spec", "group")
+		kind, _ := common.NestedString(manifest, "spec", "names", "kind")
+		plural, _ := common.NestedString(manifest, "spec", "names", "plural")
+		singular, _ := common.NestedString(manifest, "spec", "names", "singular")
+		singular = cmp.Or(singular, strings.ToLower(kind))
+		if group == "" || kind == "" || plural == "" {
+			continue
+		}
+
+		scope := meta.RESTScopeNamespace
+		if scopeName, _ := common.NestedString(manifest, "spec", "scope"); scopeName == "Cluster" {
+			scope = meta.RESTScopeRoot
+		}
+
+		versions := []string{}
+		if version, _ := common.NestedString(manifest, "spec", "version"); version != "" {
+			versions = append(versions, version)
+		}
+		if specVersions, ok := common.NestedSlice(manifest, "spec", "versions"); ok {
+			for _, specVersion := range specVersions {
+				if name, ok := specVersion["name"].(string); ok {
+					versions = append(versions, name)
+				}
+			}
+		}
 
-	for k, v := range input {
-		result[k] = &unstructured.Unstructured{Object: v}
+		for _, version := range versions {
+			gv := schema.GroupVersion{Group: group, Version: version}
+			mapper.AddSpecific(gv.WithKind(kind), gv.WithResource(plural), gv.WithResource(singular), scope)
+		}
+	}
+}
+
+// loadObjects loads the objects of a yaml file, or all yaml files of a directory.
+func loadObjects(location string) ([]map[string]interface{}, error) {
+	var files []string
+	err := filepath.WalkDir(location, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		extension := filepath.Ext(file)
+		if !entry.IsDir() && (file == location || extension == ".yaml" || extension == ".yml") {
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return result
+	objects := make([]map[string]interface{}, 0)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		manifests, err := parseYamlFile(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		for _, manifest := range manifests {
+			objects = append(objects, manifest)
+		}
+	}
+	return objects, nil
 }
@@ -44,3 +44,88 @@ func TestKubernetesFakeClientProvider(t *testing.T) {
 	_, err = client.Namespace("default").Get(context.Background(), "notexisting", v1.GetOptions{})
 	assert.Error(t, err)
 }
+
+func TestKubernetesFakeClientProviderWithoutScheme(t *testing.T) {
+	k := KubernetesFakeClientProvider{
+		Objects: []map[string]interface{}{
+			newMap("v1", "Namespace", "", "unittest"),
+			newMap("networking.k8s.io/v1", "Ingress", "", "unittest"),
+		},
+	}
+
+	client, namespaced, err := k.GetClientFor("v1", "Namespace")
+	assert.NoError(t, err)
+	assert.False(t, namespaced)
+	item, err := client.Get(context.Background(), "unittest", v1.GetOptions{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "unittest", item.GetName())
+	}
+
+	client, namespaced, err = k.GetClientFor("networking.k8s.io/v1", "Ingress")
+	assert.NoError(t, err)
+	assert.True(t, namespaced)
+	item, err = client.Namespace("default").Get(context.Background(), "unittest", v1.GetOptions{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "default", item.GetNamespace())
+	}
+}
+
+func TestKubernetesFakeClientProviderWithCustomResourceDefinition(t *testing.T) {
+	crd := newMap("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "policies.example.com")
+	crd["spec"] = map[string]interface{}{
+		"group":    "example.com",
+		"scope":    "Cluster",
+		"names":    map[string]interface{}{"kind": "Policy", "plural": "policies"},
+		"versions": []interface{}{map[string]interface{}{"name": "v1alpha1"}},
+	}
+	k := KubernetesFakeClientProvider{
+		Objects: []map[string]interface{}{crd, newMap("example.com/v1alpha1", "Policy", "", "unittest")},
+	}
+
+	client, namespaced, err := k.GetClientFor("example.com/v1alpha1", "Policy")
+	assert.NoError(t, err)
+	assert.False(t, namespaced)
+	_, err = client.Get(context.Background(), "unittest", v1.GetOptions{})
+	assert.NoError(t, err)
+}
+
+func TestKubernetesFakeClientProviderListNamespacedWithLabelSelector(t *testing.T) {
+	web := newMap("v1", "Pod", "apps", "web")
+	web["metadata"].(map[string]interface{})["labels"] = map[string]interface{}{"app": "web"}
+	k := KubernetesFakeClientProvider{
+		Objects: []map[string]interface{}{web, newMap("v1", "Pod", "apps", "worker"), newMap("v1", "Pod", "monitoring", "monitor")},
+	}
+
+	client, _, err := k.GetClientFor("v1", "Pod")
+	assert.NoError(t, err)
+
+	items, err := client.Namespace("apps").List(context.Background(), v1.ListOptions{})
+	if assert.NoError(t, err) {
+		assert.Len(t, items.Items, 2)
+	}
+	items, err = client.List(context.Background(), v1.ListOptions{})
+	if assert.NoError(t, err) {
+		assert.Len(t, items.Items, 3)
+	}
+	items, err = client.Namespace("apps").List(context.Background(), v1.ListOptions{LabelSelector: "app=web"})
+	if assert.NoError(t, err) && assert.Len(t, items.Items, 1) {
+		assert.Equal(t, "web", items.Items[0].GetName())
+	}
+}
+
+func TestKubernetesFakeClientProviderWithFiles(t *testing.T) {
+	k := KubernetesFakeClientProvider{
+		Files: []string{"testdata/chart-k8s-provider/tests/objects"},
+	}
+
+	client, _, err := k.GetClientFor("v1", "Pod")
+	assert.NoError(t, err)
+	_, err = client.Namespace("monitoring").Get(context.Background(), "monitor", v1.GetOptions{})
+	assert.NoError(t, err)
+
+	k = KubernetesFakeClientProvider{
+		Files: []string{"testdata/chart-k8s-provider/tests/notexisting"},
+	}
+	_, _, err = k.GetClientFor("v1", "Pod")
+	assert.ErrorContains(t, err, "kubernetesProvider.files")
+}
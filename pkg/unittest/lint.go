@@ -0,0 +1,421 @@
+package unittest
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	"github.com/helm-unittest/helm-unittest/schema"
+	log "github.com/sirupsen/logrus"
+	"github.com/xeipuuv/gojsonschema"
+	v3chart "helm.sh/helm/v3/pkg/chart"
+	v3loader "helm.sh/helm/v3/pkg/chart/loader"
+)
+
+// lintCounting stores counting numbers of the linted test suites
+type lintCounting struct {
+	suites uint
+	failed uint
+	issues uint
+}
+
+// lintedSuite the issues found in a test suite of a test suite file
+type lintedSuite struct {
+	name   string
+	file   string
+	issues []string
+}
+
+// LintV3 checks the test suite files of the charts in ChartPaths without running the tests. The test suites
+// are validated against the schema of the test suite files, and checked for templates and values files which
+// do not exist, duplicate test names, assertions without effect and document indices out of range.
+func (tr *TestRunner) LintV3(ChartPaths []string) bool {
+	allPassed := true
+	counting := lintCounting{}
+	for _, chartPath := range ChartPaths {
+		chart, err := v3loader.Load(chartPath)
+		if err != nil {
+			tr.printErroredChartHeader(err)
+			allPassed = false
+			continue
+		}
+
+		tr.printChartHeader(chart.Name(), chartPath)
+		linted, err := tr.lintV3Chart(chartPath, chartPath, chart.Name(), chart)
+		if err != nil {
+			tr.printErroredChartHeader(err)
+			allPassed = false
+			continue
+		}
+		for _, suite := range linted {
+			tr.printLintedSuite(suite)
+			counting.suites++
+			if len(suite.issues) > 0 {
+				counting.failed++
+				counting.issues += uint(len(suite.issues))
+				allPassed = false
+			}
+		}
+	}
+
+	tr.Printer.Println(fmt.Sprintf("\nLint: %s\n", counting.sprint(tr)), 0)
+	return allPassed
+}
+
+// sprint returns the summary of the linted test suites.
+func (counting lintCounting) sprint(tr *TestRunner) string {
+	issues := fmt.Sprintf("%d issues", counting.issues)
+	if counting.issues > 0 {
+		issues = tr.Printer.Danger("%s", issues)
+	}
+	return fmt.Sprintf("%s in %d of %d test suites", issues, counting.failed, counting.suites)
+}
+
+// printLintedSuite prints the issues of the test suite.
+func (tr *TestRunner) printLintedSuite(suite lintedSuite) {
+	label := tr.Printer.SuccessLabel(" PASS ")
+	if len(suite.issues) > 0 {
+		label = tr.Printer.DangerLabel(" FAIL ")
+	}
+	pathToPrint := tr.Printer.Faint("%s", filepath.ToSlash(filepath.Dir(suite.file)+string(os.PathSeparator))) +
+		filepath.Base(suite.file)
+	tr.Printer.Println(fmt.Sprintf("%s %s\t%s", label, tr.Printer.Highlight("%s", suite.name), pathToPrint), 0)
+	for _, issue := range suite.issues {
+		tr.Printer.Println("- "+issue, 1)
+	}
+	if len(suite.issues) > 0 {
+		tr.Printer.Println("", 0)
+	}
+}
+
+// lintV3Chart lints the test suite files of the chart, and of the subcharts when WithSubChart is true.
+// The test suites are rendered with the chart at rootPath, like running the tests.
+func (tr *TestRunner) lintV3Chart(rootPath, chartPath, chartRoute string, chart *v3chart.Chart) ([]lintedSuite, error) {
+	testFilesSet, err := GetFiles(chartPath, tr.TestFiles, false)
+	if err != nil {
+		return nil, err
+	}
+
+	linted := make([]lintedSuite, 0, len(testFilesSet))
+	for _, file := range testFilesSet {
+		suites, err := tr.lintTestSuiteFile(rootPath, file, chartRoute, chart)
+		if err != nil {
+			return nil, err
+		}
+		linted = append(linted, suites...)
+	}
+
+	if tr.WithSubChart {
+		for _, subchart := range chart.Dependencies() {
+			subchartSuites, err := tr.lintV3Chart(
+				rootPath,
+				filepath.Join(chartPath, "charts", subchart.Metadata.Name),
+				filepath.Join(chartRoute, "charts", subchart.Metadata.Name),
+				subchart,
+			)
+			if err != nil {
+				continue
+			}
+			linted = append(linted, subchartSuites...)
+		}
+	}
+	return linted, nil
+}
+
+// lintTestSuiteFile lints each test suite of the test suite file.
+func (tr *TestRunner) lintTestSuiteFile(rootPath, file, chartRoute string, chart *v3chart.Chart) ([]lintedSuite, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	suiteSchema, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(schema.TestSuite))
+	if err != nil {
+		return nil, err
+	}
+
+	linted := make([]lintedSuite, 0)
+	for _, part := range splitterPattern.Split(string(content), -1) {
+		if len(strings.TrimSpace(part)) == 0 {
+			continue
+		}
+		result := lintedSuite{name: filepath.Base(file), file: file}
+		result.issues = append(result.issues, lintSchema(suiteSchema, part)...)
+
+		suite, err := createTestSuite(file, chartRoute, part, tr.Strict, nil, false)
+		if suite == nil {
+			continue
+		}
+		if suite.Name != "" {
+			result.name = suite.Name
+		}
+		if err != nil {
+			result.issues = append(result.issues, err.Error())
+			linted = append(linted, result)
+			continue
+		}
+		suite.polishDefaultSettings(tr.SuiteDefaults)
+
+		result.issues = append(result.issues, lintTemplates(suite, chartRoute, chart)...)
+		result.issues = append(result.issues, lintValuesFiles(suite, file)...)
+		result.issues = append(result.issues, lintTestNames(suite)...)
+		result.issues = append(result.issues, lintAssertions(suite)...)
+		result.issues = append(result.issues, lintDocumentIndices(suite, rootPath)...)
+		linted = append(linted, result)
+	}
+	return linted, nil
+}
+
+// lintSchema validates the test suite against the schema of the test suite files.
+func lintSchema(suiteSchema *gojsonschema.Schema, content string) []string {
+	jsonContent, err := common.YamlToJson(content)
+	if err != nil {
+		// The test suite is not parsed either, the error is reported when parsing the test suite.
+		return nil
+	}
+	result, err := suiteSchema.Validate(gojsonschema.NewBytesLoader(jsonContent))
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	issues := make([]string, 0, len(result.Errors()))
+	for _, schemaErr := range result.Errors() {
+		issues = append(issues, fmt.Sprintf("%s: %s", lintSchemaPath(schemaErr.Field()), schemaErr.Description()))
+	}
+	return issues
+}
+
+// lintSchemaPath returns the field of a schema error like the paths of the other issues,
+// `tests[0].asserts[1]` for `tests.0.asserts.1`.
+func lintSchemaPath(field string) string {
+	var path string
+	for _, segment := range strings.Split(field, ".") {
+		if _, err := strconv.Atoi(segment); err == nil {
+			path += "[" + segment + "]"
+		} else if path == "" {
+			path = segment
+		} else {
+			path += "." + segment
+		}
+	}
+	return path
+}
+
+// lintTemplates checks the templates of the suite, tests and assertions select a template of the chart.
+func lintTemplates(suite *TestSuite, chartRoute string, chart *v3chart.Chart) []string {
+	issues := make([]string, 0)
+	check := func(path, template string) {
+		if !templateExistsV3(chartRoute, chartRoute, template, chart) {
+			issues = append(issues, fmt.Sprintf("%s: template %q matches no template of the chart", path, template))
+		}
+	}
+
+	for idx, template := range suite.Templates {
+		check(fmt.Sprintf("templates[%d]", idx), template)
+	}
+	for idx, template := range suite.ExcludeTemplates {
+		check(fmt.Sprintf("excludeTemplates[%d]", idx), template)
+	}
+	for testIdx, test := range suite.Tests {
+		if test.Template != "" {
+			check(fmt.Sprintf("tests[%d].template", testIdx), test.Template)
+		}
+		for idx, template := range test.Templates {
+			check(fmt.Sprintf("tests[%d].templates[%d]", testIdx, idx), template)
+		}
+		for assertIdx, assertion := range test.Assertions {
+			if assertion != nil && assertion.Template != "" {
+				check(fmt.Sprintf("tests[%d].asserts[%d].template", testIdx, assertIdx), assertion.Template)
+			}
+		}
+	}
+	return issues
+}
+
+// templateExistsV3 returns whether the template name of a test suite selects a template, which is not a partial,
+// of the chart or the subcharts. The template names are matched the same way as selecting the templates to render.
+func templateExistsV3(chartRoute, currentRoute, fileName string, chart *v3chart.Chart) bool {
+	pattern := getTemplateFileNamePattern(filepath.ToSlash(filepath.Join(chartRoute, getTemplateFileName(fileName))))
+	for _, template := range chart.Templates {
+		if strings.HasPrefix(filepath.Base(template.Name), "_") {
+			continue
+		}
+		if ok, _ := regexp.MatchString(pattern, filepath.ToSlash(filepath.Join(currentRoute, template.Name))); ok {
+			return true
+		}
+	}
+	for _, dependency := range chart.Dependencies() {
+		for _, name := range dependencyNamesV3(chart, dependency) {
+			if templateExistsV3(chartRoute, filepath.Join(currentRoute, subchartPrefix, name), fileName, dependency) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// dependencyNamesV3 returns the name and the aliases of the subchart, the subchart is rendered with each alias.
+func dependencyNamesV3(chart, dependency *v3chart.Chart) []string {
+	names := []string{dependency.Name()}
+	if chart.Metadata == nil {
+		return names
+	}
+	for _, requirement := range chart.Metadata.Dependencies {
+		if requirement.Name == dependency.Name() && requirement.Alias != "" {
+			names = append(names, requirement.Alias)
+		}
+	}
+	return names
+}
+
+// lintValuesFiles checks the values files of the suite and tests exist, relative to the test suite file.
+func lintValuesFiles(suite *TestSuite, file string) []string {
+	issues := make([]string, 0)
+	check := func(path, valuesFile string) {
+		valuesPath := valuesFile
+		if !filepath.IsAbs(valuesPath) {
+			valuesPath = filepath.Join(filepath.Dir(file), valuesFile)
+		}
+		if _, err := os.Stat(valuesPath); err != nil {
+			issues = append(issues, fmt.Sprintf("%s: values file %q does not exist", path, valuesFile))
+		}
+	}
+
+	for idx, valuesFile := range suite.Values {
+		check(fmt.Sprintf("values[%d]", idx), valuesFile)
+	}
+	for testIdx, test := range suite.Tests {
+		for idx, valuesFile := range test.Values {
+			check(fmt.Sprintf("tests[%d].values[%d]", testIdx, idx), valuesFile)
+		}
+	}
+	return issues
+}
+
+// lintTestNames checks the names of the tests are unique in the suite, the snapshots are stored by test name.
+func lintTestNames(suite *TestSuite) []string {
+	issues := make([]string, 0)
+	names := make(map[string]int)
+	for idx, test := range suite.Tests {
+		if first, ok := names[test.Name]; ok {
+			issues = append(issues, fmt.Sprintf("tests[%d]: duplicate test name %q, also used by tests[%d]", idx, test.Name, first))
+			continue
+		}
+		names[test.Name] = idx
+	}
+	return issues
+}
+
+// lintAssertions checks the assertions which match every document, and have no effect.
+func lintAssertions(suite *TestSuite) []string {
+	issues := make([]string, 0)
+	for testIdx, test := range suite.Tests {
+		for assertIdx, assertion := range test.Assertions {
+			if assertion == nil {
+				continue
+			}
+			if reason := noEffectReason(assertion); reason != "" {
+				issues = append(issues, fmt.Sprintf("tests[%d].asserts[%d]: %s %s, the assertion has no effect",
+					testIdx, assertIdx, assertion.AssertType, reason))
+			}
+		}
+	}
+	return issues
+}
+
+// noEffectReason returns why the assertion matches every document, empty when the assertion has an effect.
+func noEffectReason(assertion *Assertion) string {
+	var pattern string
+	switch validator := assertion.validator.(type) {
+	case *validators.IsSubsetValidator:
+		if content, ok := validator.Content.(map[string]interface{}); validator.Content == nil || (ok && len(content) == 0) {
+			return "with empty content matches every mapping"
+		}
+		return ""
+	case *validators.MatchRegexValidator:
+		pattern = validator.Pattern
+	case *validators.MatchRegexRawValidator:
+		pattern = validator.Pattern
+	default:
+		return ""
+	}
+
+	// The pattern is not anchored, a pattern matching an empty string matches every value.
+	if matched, err := regexp.MatchString(pattern, ""); err == nil && matched {
+		return fmt.Sprintf("pattern %q matches every value", pattern)
+	}
+	return ""
+}
+
+// lintDocumentIndices renders the tests, and checks the document indices of the tests and assertions are
+// in range of the documents rendered by the templates. The tests which fail to render are not checked, and the
+// tests with a post renderer, which may change the documents, neither.
+func lintDocumentIndices(suite *TestSuite, chartPath string) []string {
+	suite.polishTestJobsPathInfo()
+	issues := make([]string, 0)
+	for testIdx, test := range suite.Tests {
+		if test.Skip.Reason != "" || suite.PostRendererConfig.Cmd != "" || test.PostRendererConfig.Cmd != "" {
+			continue
+		}
+		manifestsOfFiles, ok := lintRender(test, chartPath)
+		if !ok {
+			continue
+		}
+
+		reported := make(map[string]bool)
+		for assertIdx, assertion := range test.Assertions {
+			if assertion == nil || assertion.DocumentIndex < 0 || assertion.DocumentSelector != nil {
+				continue
+			}
+			path := fmt.Sprintf("tests[%d].asserts[%d].documentIndex", testIdx, assertIdx)
+			if test.DocumentIndex != nil {
+				path = fmt.Sprintf("tests[%d].documentIndex", testIdx)
+			}
+			for _, template := range assertion.defaultTemplates {
+				manifests, rendered := manifestsOfFiles[template]
+				if !rendered || assertion.DocumentIndex < len(manifests) {
+					continue
+				}
+				issue := fmt.Sprintf("%s: documentIndex %d is out of range, %s renders %d documents",
+					path, assertion.DocumentIndex, template, len(manifests))
+				if !reported[issue] {
+					reported[issue] = true
+					issues = append(issues, issue)
+				}
+			}
+		}
+	}
+	return issues
+}
+
+// lintRender renders the test without running the assertions, and resolves the templates of the assertions.
+func lintRender(test *TestJob, chartPath string) (map[string][]common.K8sManifest, bool) {
+	log.SetOutput(io.Discard)
+	chart, err := v3loader.Load(chartPath)
+	log.SetOutput(os.Stdout)
+	if err != nil {
+		return nil, false
+	}
+
+	test.WithConfig(*NewTestConfig(chart, &snapshot.Cache{}, WithChartPath(chartPath)))
+	userValues, err := test.getUserValues()
+	if err != nil {
+		return nil, false
+	}
+	outputOfFiles, renderSucceed, err := test.renderV3Chart([]byte(userValues))
+	if err != nil || !renderSucceed {
+		return nil, false
+	}
+	manifestsOfFiles, err := test.parseManifestsFromOutputOfFiles(outputOfFiles)
+	if err != nil {
+		return nil, false
+	}
+	test.polishAssertionsTemplate(chart.Name(), outputOfFiles)
+	return manifestsOfFiles, true
+}
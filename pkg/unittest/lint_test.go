@@ -0,0 +1,87 @@
+package unittest_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	"github.com/stretchr/testify/assert"
+)
+
+const lintSuite = `suite: lint
+templates:
+  - service.yaml
+  - missing.yaml
+values:
+  - values/missing.yaml
+tests:
+  - it: should render
+    unknown: true
+    asserts:
+      - isKind:
+          of: Service
+  - it: should render
+    asserts:
+      - matchRegex:
+          path: metadata.name
+          pattern: .*
+      - isSubset:
+          path: metadata
+          content: {}
+---
+suite: lint render
+templates:
+  - service.yaml
+tests:
+  - it: should render
+    asserts:
+      - isKind:
+          of: Service
+        documentIndex: 1
+---
+suite: lint ok
+templates:
+  - service.yaml
+tests:
+  - it: should render
+    asserts:
+      - isKind:
+          of: Service
+        documentIndex: 0
+`
+
+func TestLintV3(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "basic")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS(testV3BasicChart)))
+	assert.NoError(t, os.RemoveAll(filepath.Join(chartPath, "tests")))
+	assert.NoError(t, os.Mkdir(filepath.Join(chartPath, "tests"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "lint_test.yaml"), []byte(lintSuite), 0644))
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/*_test.yaml"},
+	}
+	assert.False(t, runner.LintV3([]string{chartPath}))
+	assert.Contains(t, buffer.String(), ` FAIL  lint	`+filepath.Join(chartPath, "tests")+`/lint_test.yaml
+	- tests[0]: Additional property unknown is not allowed
+	- templates[1]: template "missing.yaml" matches no template of the chart
+	- values[0]: values file "values/missing.yaml" does not exist
+	- tests[1]: duplicate test name "should render", also used by tests[0]
+	- tests[1].asserts[0]: matchRegex pattern ".*" matches every value, the assertion has no effect
+	- tests[1].asserts[1]: isSubset with empty content matches every mapping, the assertion has no effect
+`)
+	assert.Contains(t, buffer.String(), ` FAIL  lint render	`+filepath.Join(chartPath, "tests")+`/lint_test.yaml
+	- tests[0].asserts[0].documentIndex: documentIndex 1 is out of range, basic/templates/service.yaml renders 1 documents
+`)
+	assert.Contains(t, buffer.String(), " PASS  lint ok\t")
+	assert.Contains(t, buffer.String(), "Lint: 7 issues in 2 of 3 test suites")
+
+	// The test suites of the chart have no issues.
+	buffer.Reset()
+	assert.True(t, runner.LintV3([]string{testV3BasicChart}), buffer.String())
+	assert.Contains(t, buffer.String(), "Lint: 0 issues in 0 of 18 test suites")
+}
@@ -0,0 +1,110 @@
+package unittest
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	v3chart "helm.sh/helm/v3/pkg/chart"
+)
+
+// mockTemplateFile the file of the mocked templates, which is added to the root of the chart.
+// The engine parses templates with the least path separators last, so the mocked templates override the chart templates.
+const mockTemplateFile = "_helm-unittest-mocks.tpl"
+
+// Mocks replaces named templates and template functions of the chart, to test templates in isolation
+// from (library chart) helpers or the cluster.
+type Mocks struct {
+	Templates map[string]string         `yaml:"templates"`
+	Functions map[string][]MockFunction `yaml:"functions"`
+}
+
+// MockFunction the value returned by the mocked function when called with the arguments.
+// A mock without arguments is returned for all calls which are not matched by the other mocks.
+type MockFunction struct {
+	Args   []interface{} `yaml:"args"`
+	Return interface{}   `yaml:"return"`
+}
+
+// mockV3Chart adds the mocked templates to the (filtered) chart, and returns the mocked functions
+// which override the template functions of the engine.
+func (m *Mocks) mockV3Chart(chart *v3chart.Chart) (template.FuncMap, error) {
+	functions := make(template.FuncMap, len(m.Functions))
+	for _, name := range sortedKeys(m.Functions) {
+		if name == "include" || name == "tpl" {
+			return nil, fmt.Errorf("mocks.functions: %s can not be mocked, mock the template instead", name)
+		}
+		function, err := mockFunction(name, m.Functions[name])
+		if err != nil {
+			return nil, err
+		}
+		functions[name] = function
+	}
+
+	if len(m.Templates) > 0 {
+		var builder strings.Builder
+		for _, name := range sortedKeys(m.Templates) {
+			fmt.Fprintf(&builder, "{{- define %s -}}\n%s\n{{- end -}}\n", strconv.Quote(name), m.Templates[name])
+		}
+
+		templates := make([]*v3chart.File, 0, len(chart.Templates)+1)
+		templates = append(templates, chart.Templates...)
+		chart.Templates = append(templates, &v3chart.File{Name: mockTemplateFile, Data: []byte(builder.String())})
+	}
+	return functions, nil
+}
+
+// mockFunction returns the function which returns the value of the mock matching the json of the arguments.
+// The values are returned as decoded from json, like the values of the functions calling the cluster.
+func mockFunction(name string, mocks []MockFunction) (func(args ...interface{}) (interface{}, error), error) {
+	returns := make(map[string][]byte, len(mocks))
+	var fallback []byte
+	for _, mock := range mocks {
+		value, err := json.Marshal(mock.Return)
+		if err != nil {
+			return nil, fmt.Errorf("mocks.functions.%s: %w", name, err)
+		}
+		if mock.Args == nil {
+			fallback = value
+			continue
+		}
+
+		args, err := json.Marshal(mock.Args)
+		if err != nil {
+			return nil, fmt.Errorf("mocks.functions.%s: %w", name, err)
+		}
+		if _, ok := returns[string(args)]; !ok {
+			returns[string(args)] = value
+		}
+	}
+
+	return func(args ...interface{}) (interface{}, error) {
+		called, err := json.Marshal(append([]interface{}{}, args...))
+		if err != nil {
+			return nil, err
+		}
+		value, ok := returns[string(called)]
+		if !ok {
+			value = fallback
+		}
+		if value == nil {
+			return nil, fmt.Errorf("no mock of function %s for arguments %s", name, called)
+		}
+
+		var result interface{}
+		err = json.Unmarshal(value, &result)
+		return result, err
+	}, nil
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,61 @@
+package unittest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v3chart "helm.sh/helm/v3/pkg/chart"
+)
+
+func TestMockV3ChartAddsMockTemplate(t *testing.T) {
+	chart := &v3chart.Chart{
+		Metadata:  &v3chart.Metadata{Name: "chart"},
+		Templates: []*v3chart.File{{Name: "templates/a.yaml", Data: []byte("region: {{ clusterRegion }}")}},
+	}
+	original := chart.Templates[0]
+
+	mocks := &Mocks{
+		Templates: map[string]string{"chart.labels": "app: mocked"},
+		Functions: map[string][]MockFunction{"clusterRegion": {{Return: "eu-west-1"}}},
+	}
+	functions, err := mocks.mockV3Chart(chart)
+	assert.NoError(t, err)
+
+	a := assert.New(t)
+	a.Len(chart.Templates, 2)
+	a.Same(original, chart.Templates[0])
+	a.Equal(mockTemplateFile, chart.Templates[1].Name)
+	a.Equal(`{{- define "chart.labels" -}}
+app: mocked
+{{- end -}}
+`, string(chart.Templates[1].Data))
+	a.Contains(functions, "clusterRegion")
+}
+
+func TestMockFunctionReturnsTheValueOfTheMatchingArgs(t *testing.T) {
+	function, err := mockFunction("lookup", []MockFunction{
+		{Args: []interface{}{"v1", "Secret", "database", "db"}, Return: map[string]interface{}{"data": map[string]interface{}{"port": 5432}}},
+		{Args: []interface{}{}, Return: "no-args"},
+	})
+	assert.NoError(t, err)
+
+	a := assert.New(t)
+	value, err := function("v1", "Secret", "database", "db")
+	a.NoError(err)
+	a.Equal(map[string]interface{}{"data": map[string]interface{}{"port": float64(5432)}}, value)
+
+	value, err = function()
+	a.NoError(err)
+	a.Equal("no-args", value)
+
+	_, err = function("v1", "Secret", "database", "other")
+	a.EqualError(err, `no mock of function lookup for arguments ["v1","Secret","database","other"]`)
+}
+
+func TestMockV3ChartRejectsInclude(t *testing.T) {
+	chart := &v3chart.Chart{Metadata: &v3chart.Metadata{Name: "chart"}}
+	mocks := &Mocks{Functions: map[string][]MockFunction{"include": {{Return: ""}}}}
+
+	_, err := mocks.mockV3Chart(chart)
+	assert.ErrorContains(t, err, "include can not be mocked")
+}
@@ -10,6 +10,7 @@ import (
 
 type TestConfig struct {
 	targetChart         *v3chart.Chart
+	chartPath           string
 	cache               *snapshot.Cache
 	renderPath          string
 	failFast            bool
@@ -46,6 +47,12 @@ func WithRenderPath(path string) LoadTestOptionsFunc {
 	}
 }
 
+func WithChartPath(path string) LoadTestOptionsFunc {
+	return func(c *TestConfig) {
+		c.chartPath = path
+	}
+}
+
 func WithDocumentSelector(selector *valueutils.DocumentSelector) LoadTestOptionsFunc {
 	return func(c *TestConfig) {
 		if selector != nil {
@@ -69,34 +76,49 @@ func WithSkipEmptyTemplate(config bool) LoadTestOptionsFunc {
 }
 
 type AssertionConfig struct {
-	templatesResult     map[string][]common.K8sManifest
-	snapshotComparer    validators.SnapshotComparer
-	renderSucceed       bool
-	failFast            bool
-	isSkipEmptyTemplate bool
-	didPostRender       bool
-	renderError         error
+	templatesResult         map[string][]common.K8sManifest
+	allTemplatesResult      map[string][]common.K8sManifest
+	previousTemplatesResult map[string][]common.K8sManifest
+	snapshotComparer        validators.SnapshotComparer
+	snapshotRedaction       snapshot.Redaction
+	inlineSnapshotComparer  validators.InlineSnapshotComparer
+	renderSucceed           bool
+	failFast                bool
+	isSkipEmptyTemplate     bool
+	didPostRender           bool
+	renderError             error
+	templateError           *validators.TemplateError
 }
 
 // AssertionConfigBuilder Required to simplify tests
 type AssertionConfigBuilder struct {
-	TemplatesResult     map[string][]common.K8sManifest
-	SnapshotComparer    validators.SnapshotComparer
-	RenderSucceed       bool
-	FailFast            bool
-	DidPostRender       bool
-	RenderError         error
-	IsSkipEmptyTemplate bool
+	TemplatesResult         map[string][]common.K8sManifest
+	AllTemplatesResult      map[string][]common.K8sManifest
+	PreviousTemplatesResult map[string][]common.K8sManifest
+	SnapshotComparer        validators.SnapshotComparer
+	SnapshotRedaction       snapshot.Redaction
+	InlineSnapshotComparer  validators.InlineSnapshotComparer
+	RenderSucceed           bool
+	FailFast                bool
+	DidPostRender           bool
+	RenderError             error
+	TemplateError           *validators.TemplateError
+	IsSkipEmptyTemplate     bool
 }
 
 func (b AssertionConfigBuilder) Build() AssertionConfig {
 	return AssertionConfig{
-		templatesResult:     b.TemplatesResult,
-		snapshotComparer:    b.SnapshotComparer,
-		renderSucceed:       b.RenderSucceed,
-		failFast:            b.FailFast,
-		didPostRender:       b.DidPostRender,
-		renderError:         b.RenderError,
-		isSkipEmptyTemplate: b.IsSkipEmptyTemplate,
+		templatesResult:         b.TemplatesResult,
+		allTemplatesResult:      b.AllTemplatesResult,
+		previousTemplatesResult: b.PreviousTemplatesResult,
+		snapshotComparer:        b.SnapshotComparer,
+		snapshotRedaction:       b.SnapshotRedaction,
+		inlineSnapshotComparer:  b.InlineSnapshotComparer,
+		renderSucceed:           b.RenderSucceed,
+		failFast:                b.FailFast,
+		didPostRender:           b.DidPostRender,
+		renderError:             b.RenderError,
+		templateError:           b.TemplateError,
+		isSkipEmptyTemplate:     b.IsSkipEmptyTemplate,
 	}
 }
@@ -39,6 +39,14 @@ func TestWithRenderPath(t *testing.T) {
 	assert.Equal(t, "/path/to/render", config.renderPath)
 }
 
+func TestWithChartPath(t *testing.T) {
+	chart := &v3chart.Chart{}
+	cache := &snapshot.Cache{}
+	config := NewTestConfig(chart, cache, WithChartPath("/path/to/chart"))
+
+	assert.Equal(t, "/path/to/chart", config.chartPath)
+}
+
 func TestWithPostRendererConfig(t *testing.T) {
 	chart := &v3chart.Chart{}
 	cache := &snapshot.Cache{}
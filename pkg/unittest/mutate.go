@@ -0,0 +1,341 @@
+package unittest
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/results"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	v3chart "helm.sh/helm/v3/pkg/chart"
+	v3loader "helm.sh/helm/v3/pkg/chart/loader"
+)
+
+// The mutation operators of the mutate command
+const (
+	flipIfOperator        = "flip if"
+	removeDefaultOperator = "remove default"
+	removeQuoteOperator   = "remove quote"
+	deleteLineOperator    = "delete line"
+)
+
+var (
+	// actionPattern matches the actions of a template, like `{{ .Values.name | quote }}`
+	actionPattern = regexp.MustCompile(`(?s)\{\{.*?\}\}`)
+	// commentActionPattern matches the comments of a template, which are not mutated
+	commentActionPattern = regexp.MustCompile(`^\{\{-?\s*/\*`)
+	// ifActionPattern matches the condition of the if and else if actions
+	ifActionPattern = regexp.MustCompile(`(?s)^(\{\{-?\s*(?:else\s+)?if\s+)(.*?)(\s*-?\}\})$`)
+	// defaultPipePattern matches the default of a pipeline, like `| default "nginx"`
+	defaultPipePattern = regexp.MustCompile(`\s*\|\s*default\s+(?:"[^"]*"|'[^']*'|\([^()]*\)|[^\s|()]+)`)
+	// defaultCallPattern matches the default function with its default value, like `default "nginx" `
+	defaultCallPattern = regexp.MustCompile(`\bdefault\s+(?:"[^"]*"|'[^']*'|\([^()]*\)|[^\s|()]+)\s+`)
+	// quotePipePattern matches the quote and squote of a pipeline, like `| quote`
+	quotePipePattern = regexp.MustCompile(`\s*\|\s*s?quote\b`)
+	// quoteCallPattern matches the quote and squote functions, like `quote `
+	quoteCallPattern = regexp.MustCompile(`\bs?quote\s+`)
+	// callArgumentPattern matches the start of the argument following a function call
+	callArgumentPattern = regexp.MustCompile(`^[^\s|)}-]`)
+)
+
+// mutant a change of a template of the chart, or a deleted line of the rendered output of a template
+type mutant struct {
+	// the name of the template in the chart, like `templates/deployment.yaml`
+	template string
+	operator string
+	// the line of the change in the template, zero for the deleted lines of the rendered output
+	line     int
+	original string
+	mutated  string
+	// the mutated content of the template, empty for the deleted lines of the rendered output
+	source string
+}
+
+// mutantResult the result of a mutant, which survives when no test fails for the mutant
+type mutantResult struct {
+	*mutant
+	// the suite with the failed test, empty when the mutant survived
+	killedBy string
+}
+
+// description returns the change of the mutant.
+func (m *mutant) description() string {
+	if m.operator == deleteLineOperator {
+		return fmt.Sprintf("rendered output, %s: %s", m.operator, m.original)
+	}
+	return fmt.Sprintf("line %d, %s: %s => %s", m.line, m.operator, m.original, m.mutated)
+}
+
+// applyToChart replaces the content of the mutated template of the chart.
+func (m *mutant) applyToChart(chart *v3chart.Chart) {
+	if m == nil || m.source == "" {
+		return
+	}
+	for _, template := range chart.Templates {
+		if template.Name == m.template {
+			template.Data = []byte(m.source)
+		}
+	}
+}
+
+// applyToOutput deletes the lines of the rendered output of the template.
+func (m *mutant) applyToOutput(chartRoute string, outputOfFiles map[string]string) {
+	if m == nil || m.operator != deleteLineOperator {
+		return
+	}
+	file := filepath.ToSlash(filepath.Join(chartRoute, m.template))
+	rendered, found := outputOfFiles[file]
+	if !found {
+		return
+	}
+	lines := strings.Split(rendered, "\n")
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if strings.TrimSpace(line) != m.original {
+			kept = append(kept, line)
+		}
+	}
+	outputOfFiles[file] = strings.Join(kept, "\n")
+}
+
+// MutateV3 applies mutations to the templates of the charts, flipping if conditions, removing default and quote calls
+// and deleting lines of the rendered output, and runs the suites of the mutated template for each mutant. A mutant
+// survives when all tests pass, the surviving mutants are printed per template. The tests have to pass without
+// mutations. Returns false when a mutant survived.
+func (tr *TestRunner) MutateV3(chartPaths []string) bool {
+	passed := true
+	for _, chartPath := range chartPaths {
+		chart, err := v3loader.Load(chartPath)
+		if err != nil {
+			tr.printErroredChartHeader(err)
+			passed = false
+			continue
+		}
+		tr.printChartHeader(chart.Name(), chartPath)
+		mutantResults, err := tr.mutateChart(chartPath, chart)
+		if err != nil {
+			tr.Printer.Println(tr.Printer.Danger("Error: %s", err), 1)
+			passed = false
+			continue
+		}
+		passed = tr.printMutantResults(mutantResults) && passed
+		if !passed && tr.Failfast {
+			break
+		}
+	}
+	return passed
+}
+
+// mutateChart runs the suites of the chart for each mutant of the templates of the chart.
+func (tr *TestRunner) mutateChart(chartPath string, chart *v3chart.Chart) ([]mutantResult, error) {
+	if failedSuite, err := tr.runMutant(chartPath, chart, nil); err != nil {
+		return nil, err
+	} else if failedSuite != "" {
+		return nil, fmt.Errorf("the suite %s fails without mutations, the tests have to pass to mutate the chart", failedSuite)
+	}
+
+	mutants := templateMutants(chart)
+	mutants = append(mutants, outputMutants(chartPath, chart)...)
+	mutantResults := make([]mutantResult, 0, len(mutants))
+	for _, m := range mutants {
+		killedBy, err := tr.runMutant(chartPath, chart, m)
+		if err != nil {
+			return nil, err
+		}
+		mutantResults = append(mutantResults, mutantResult{mutant: m, killedBy: killedBy})
+	}
+	return mutantResults, nil
+}
+
+// runMutant runs the suites of the chart which render the mutated template, and returns the name of the first
+// failed suite, empty when all suites passed. The snapshots are compared, but not updated.
+func (tr *TestRunner) runMutant(chartPath string, chart *v3chart.Chart, m *mutant) (string, error) {
+	testSuites, err := tr.getTestSuites(chartPath, chart.Name())
+	if err != nil {
+		return "", err
+	}
+	layout, err := snapshot.ParseLayout(string(tr.SnapshotLayout))
+	if err != nil {
+		return "", err
+	}
+	for _, suite := range testSuites {
+		if m != nil && !m.rendersIn(suite, chart) {
+			continue
+		}
+		cache, err := snapshot.CreateSnapshotOfSuite(suite.SnapshotFileUrl(), false, layout)
+		if err != nil {
+			return "", err
+		}
+		cache.IsCI = true
+		if tr.Deterministic && suite.Functions == nil {
+			suite.Functions = &FunctionStubs{}
+		}
+		suite.mutant = m
+		result := suite.RunV3(chartPath, cache, false, "", &results.TestSuiteResult{})
+		if !result.Passed {
+			return suite.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// rendersIn returns true when the suite renders the mutated template, the partials are rendered by all suites.
+func (m *mutant) rendersIn(suite *TestSuite, chart *v3chart.Chart) bool {
+	templatesToAssert := suite.Templates
+	if len(templatesToAssert) == 0 {
+		templatesToAssert = []string{multiWildcard}
+	}
+	for _, template := range filterV3Templates(chart.Name(), chart.Name(), templatesToAssert, suite.ExcludeTemplates, chart) {
+		if template.Name == m.template {
+			return true
+		}
+	}
+	return false
+}
+
+// templateMutants returns the mutants of the actions of the templates of the chart.
+func templateMutants(chart *v3chart.Chart) []*mutant {
+	templates := append([]*v3chart.File{}, chart.Templates...)
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+
+	mutants := []*mutant{}
+	for _, template := range templates {
+		content := string(template.Data)
+		for _, location := range actionPattern.FindAllStringIndex(content, -1) {
+			action := content[location[0]:location[1]]
+			if commentActionPattern.MatchString(action) {
+				continue
+			}
+			line := strings.Count(content[:location[0]], "\n") + 1
+			for _, mutation := range actionMutations(action) {
+				mutants = append(mutants, &mutant{
+					template: template.Name,
+					operator: mutation.operator,
+					line:     line,
+					original: action,
+					mutated:  mutation.mutated,
+					source:   content[:location[0]] + mutation.mutated + content[location[1]:],
+				})
+			}
+		}
+	}
+	return mutants
+}
+
+// actionMutation a mutated action of a template
+type actionMutation struct {
+	operator string
+	mutated  string
+}
+
+// actionMutations returns the mutations of the action, each occurrence of default and quote is removed separately.
+func actionMutations(action string) []actionMutation {
+	mutations := []actionMutation{}
+	if match := ifActionPattern.FindStringSubmatch(action); match != nil {
+		mutations = append(mutations, actionMutation{flipIfOperator, match[1] + "not (" + match[2] + ")" + match[3]})
+	}
+	removeEach := func(operator string, pattern *regexp.Regexp, call bool) {
+		for _, location := range pattern.FindAllStringIndex(action, -1) {
+			// A function call is followed by its argument, the functions of a pipeline are matched by the pipe pattern.
+			if call && (strings.HasSuffix(strings.TrimSpace(action[:location[0]]), "|") || !callArgumentPattern.MatchString(action[location[1]:])) {
+				continue
+			}
+			mutations = append(mutations, actionMutation{operator, action[:location[0]] + action[location[1]:]})
+		}
+	}
+	removeEach(removeDefaultOperator, defaultPipePattern, false)
+	removeEach(removeDefaultOperator, defaultCallPattern, true)
+	removeEach(removeQuoteOperator, quotePipePattern, false)
+	removeEach(removeQuoteOperator, quoteCallPattern, true)
+	return mutations
+}
+
+// outputMutants returns the mutants deleting each distinct line of the output of the templates rendered with the
+// default values, the blank lines, comments and document separators are kept. No mutants are returned when the chart
+// does not render with the default values.
+func outputMutants(chartPath string, chart *v3chart.Chart) []*mutant {
+	renderChart, err := loadChartSilently(chartPath)
+	if err != nil {
+		return nil
+	}
+	job := &TestJob{
+		Name:                 "mutate",
+		Functions:            &FunctionStubs{},
+		chartRoute:           chart.Name(),
+		definitionFile:       filepath.Join(chartPath, "Chart.yaml"),
+		requireRenderSuccess: true,
+	}
+	job.WithConfig(*NewTestConfig(renderChart, &snapshot.Cache{}, WithChartPath(chartPath)))
+	userValues, err := job.getUserValues()
+	if err != nil {
+		return nil
+	}
+	outputOfFiles, _, err := job.renderV3Chart([]byte(userValues))
+	if err != nil {
+		return nil
+	}
+
+	mutants := []*mutant{}
+	prefix := chart.Name() + "/"
+	for _, file := range sortedKeys(outputOfFiles) {
+		template, found := strings.CutPrefix(file, prefix)
+		if !found || strings.HasPrefix(filepath.Base(template), "_") {
+			continue
+		}
+		deleted := map[string]bool{}
+		for _, line := range strings.Split(outputOfFiles[file], "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || line == "---" || strings.HasPrefix(line, "#") || deleted[line] {
+				continue
+			}
+			deleted[line] = true
+			mutants = append(mutants, &mutant{template: template, operator: deleteLineOperator, original: line})
+		}
+	}
+	return mutants
+}
+
+// printMutantResults prints the killed and surviving mutants per template, and the mutation score of the chart.
+// Returns false when a mutant survived.
+func (tr *TestRunner) printMutantResults(mutantResults []mutantResult) bool {
+	byTemplate := map[string][]mutantResult{}
+	for _, result := range mutantResults {
+		byTemplate[result.template] = append(byTemplate[result.template], result)
+	}
+
+	survived := 0
+	for _, template := range sortedKeys(byTemplate) {
+		templateSurvived := []mutantResult{}
+		for _, result := range byTemplate[template] {
+			if result.killedBy == "" {
+				templateSurvived = append(templateSurvived, result)
+			}
+		}
+		survived += len(templateSurvived)
+		summary := fmt.Sprintf("%d mutants, %d killed, %d survived",
+			len(byTemplate[template]), len(byTemplate[template])-len(templateSurvived), len(templateSurvived))
+		if len(templateSurvived) == 0 {
+			tr.Printer.Println(fmt.Sprintf("%s %s\t%s", tr.Printer.SuccessLabel(" PASS "), template, tr.Printer.Faint("%s", summary)), 1)
+			continue
+		}
+		tr.Printer.Println(fmt.Sprintf("%s %s\t%s", tr.Printer.DangerLabel(" FAIL "), template, tr.Printer.Faint("%s", summary)), 1)
+		for _, result := range templateSurvived {
+			tr.Printer.Println(tr.Printer.Danger("- %s", result.description()), 2)
+		}
+		tr.Printer.Println("", 0)
+	}
+
+	score := 100.0
+	if len(mutantResults) > 0 {
+		score = float64(len(mutantResults)-survived) * 100 / float64(len(mutantResults))
+	}
+	summary := fmt.Sprintf("Mutation score: %.1f%%, %d of %d mutants killed", score, len(mutantResults)-survived, len(mutantResults))
+	if survived > 0 {
+		tr.Printer.Println(tr.Printer.Danger("%s", summary), 1)
+	} else {
+		tr.Printer.Println(tr.Printer.Success("%s", summary), 1)
+	}
+	return survived == 0
+}
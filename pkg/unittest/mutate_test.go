@@ -0,0 +1,148 @@
+package unittest_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest"
+)
+
+// writeMutateChart writes a chart with a ConfigMap template and the test suite.
+func writeMutateChart(t *testing.T, suite string) string {
+	t.Helper()
+	chartPath := filepath.Join(t.TempDir(), "mutate")
+	assert.NoError(t, os.MkdirAll(filepath.Join(chartPath, "templates"), 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(chartPath, "tests"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "Chart.yaml"), []byte("apiVersion: v2\nname: mutate\nversion: 0.1.0\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "values.yaml"), []byte("name: \"\"\ndebug: false\nlevel: info\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "templates", "configmap.yaml"), []byte(`{{- /* the settings of the application */}}
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Values.name | default "app" }}
+data:
+  {{- if .Values.debug }}
+  debug: {{ .Values.debug | quote }}
+  {{- end }}
+  level: {{ quote .Values.level }}
+`), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "configmap_test.yaml"), []byte(suite), 0644))
+	return chartPath
+}
+
+func TestV3RunnerMutateReportsSurvivingMutants(t *testing.T) {
+	chartPath := writeMutateChart(t, `suite: configmap
+templates:
+  - configmap.yaml
+tests:
+  - it: should render the defaults
+    asserts:
+      - isKind:
+          of: ConfigMap
+      - isAPIVersion:
+          of: v1
+      - equal:
+          path: metadata.name
+          value: app
+      - notExists:
+          path: data.debug
+      - equal:
+          path: data.level
+          value: info
+  - it: should render the debug setting as string
+    set:
+      debug: true
+    asserts:
+      - equal:
+          path: data.debug
+          value: "true"
+`)
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{Printer: printer.NewPrinter(buffer, nil), TestFiles: []string{"tests/*_test.yaml"}}
+	passed := runner.MutateV3([]string{chartPath})
+
+	assert.False(t, passed)
+	assert.Contains(t, buffer.String(), " FAIL  templates/configmap.yaml\t10 mutants, 9 killed, 1 survived")
+	// Removing the quote of a string value renders the same document.
+	assert.Contains(t, buffer.String(), "- line 10, remove quote: {{ quote .Values.level }} => {{ .Values.level }}")
+	assert.Contains(t, buffer.String(), "Mutation score: 90.0%, 9 of 10 mutants killed")
+}
+
+func TestV3RunnerMutateKillsAllMutants(t *testing.T) {
+	chartPath := writeMutateChart(t, `suite: configmap
+templates:
+  - configmap.yaml
+tests:
+  - it: should render the defaults
+    asserts:
+      - matchSnapshot: {}
+  - it: should render the debug setting as string
+    set:
+      debug: true
+      level: "1"
+    asserts:
+      - equal:
+          path: data.debug
+          value: "true"
+      - equal:
+          path: data.level
+          value: "1"
+`)
+	// The snapshots are stored by the tests, and compared with the output of the mutants.
+	runner := TestRunner{Printer: printer.NewPrinter(new(bytes.Buffer), nil), TestFiles: []string{"tests/*_test.yaml"}}
+	assert.True(t, runner.RunV3([]string{chartPath}))
+
+	buffer := new(bytes.Buffer)
+	runner = TestRunner{Printer: printer.NewPrinter(buffer, nil), TestFiles: []string{"tests/*_test.yaml"}}
+	passed := runner.MutateV3([]string{chartPath})
+
+	assert.True(t, passed, buffer.String())
+	assert.Contains(t, buffer.String(), " PASS  templates/configmap.yaml\t10 mutants, 10 killed, 0 survived")
+	assert.Contains(t, buffer.String(), "Mutation score: 100.0%, 10 of 10 mutants killed")
+}
+
+func TestV3RunnerMutateRequiresPassingTests(t *testing.T) {
+	chartPath := writeMutateChart(t, `suite: configmap
+templates:
+  - configmap.yaml
+tests:
+  - it: should fail
+    asserts:
+      - isKind:
+          of: Secret
+`)
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{Printer: printer.NewPrinter(buffer, nil), TestFiles: []string{"tests/*_test.yaml"}}
+	passed := runner.MutateV3([]string{chartPath})
+
+	assert.False(t, passed)
+	assert.Contains(t, buffer.String(), "Error: the suite configmap fails without mutations")
+}
+
+func TestV3RunnerMutateWithoutSuitesOfTheTemplate(t *testing.T) {
+	chartPath := writeMutateChart(t, `suite: other
+templates:
+  - other.yaml
+tests:
+  - it: should render the other template
+    asserts:
+      - hasDocuments:
+          count: 1
+`)
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "templates", "other.yaml"), []byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: other\n"), 0644))
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{Printer: printer.NewPrinter(buffer, nil), TestFiles: []string{"tests/*_test.yaml"}}
+	passed := runner.MutateV3([]string{chartPath})
+
+	assert.False(t, passed)
+	// The mutants of the templates which are not rendered by any suite survive.
+	assert.Contains(t, buffer.String(), " FAIL  templates/configmap.yaml\t10 mutants, 0 killed, 10 survived")
+}
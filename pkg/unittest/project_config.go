@@ -0,0 +1,159 @@
+package unittest
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+)
+
+// ProjectConfigFile the name of the project configuration file, located in the chart or the repository root
+const ProjectConfigFile = ".helm-unittest.yaml"
+
+// ProjectConfig the defaults of the command line options and the test suites of a project,
+// the options which are not set are nil or empty.
+type ProjectConfig struct {
+	TestFiles      []string `yaml:"testFiles,omitempty"`
+	ValuesFiles    []string `yaml:"valuesFiles,omitempty"`
+	OutputFile     string   `yaml:"outputFile,omitempty"`
+	OutputType     string   `yaml:"outputType,omitempty"`
+	ChartTestsPath string   `yaml:"chartTestsPath,omitempty"`
+	SnapshotLayout string   `yaml:"snapshotLayout,omitempty"`
+	RenderPath     string   `yaml:"renderPath,omitempty"`
+	Color          *bool    `yaml:"color,omitempty"`
+	Strict         *bool    `yaml:"strict,omitempty"`
+	Failfast       *bool    `yaml:"failfast,omitempty"`
+	Deterministic  *bool    `yaml:"deterministic,omitempty"`
+	UpdateSnapshot *bool    `yaml:"updateSnapshot,omitempty"`
+	PruneSnapshots *bool    `yaml:"pruneSnapshots,omitempty"`
+	CI             *bool    `yaml:"ci,omitempty"`
+	LineDiff       *bool    `yaml:"lineDiff,omitempty"`
+	WithSubChart   *bool    `yaml:"withSubChart,omitempty"`
+	RenderDiff     *bool    `yaml:"renderDiff,omitempty"`
+	DebugPlugin    *bool    `yaml:"debugPlugin,omitempty"`
+	SuiteDefaults  `yaml:",inline"`
+}
+
+// SuiteDefaults the settings of the test suites, used when a test suite does not define the setting
+type SuiteDefaults struct {
+	Release      *ReleaseDefaults      `yaml:"release,omitempty"`
+	Capabilities *CapabilitiesDefaults `yaml:"capabilities,omitempty"`
+	PostRenderer *PostRendererConfig   `yaml:"postRenderer,omitempty"`
+	// Snapshot the paths to ignore and redact in all snapshots, in addition to the paths of the test suites
+	Snapshot *snapshot.Redaction `yaml:"snapshot,omitempty"`
+}
+
+// ReleaseDefaults the default release of the test suites
+type ReleaseDefaults struct {
+	Name      string `yaml:"name,omitempty"`
+	Namespace string `yaml:"namespace,omitempty"`
+	Revision  int    `yaml:"revision,omitempty"`
+	IsUpgrade bool   `yaml:"upgrade,omitempty"`
+}
+
+// CapabilitiesDefaults the default capabilities of the test suites
+type CapabilitiesDefaults struct {
+	MajorVersion string   `yaml:"majorVersion,omitempty"`
+	MinorVersion string   `yaml:"minorVersion,omitempty"`
+	APIVersions  []string `yaml:"apiVersions,omitempty"`
+}
+
+// FindProjectConfig returns the path of the project configuration file of the chart, looking in the chart
+// and the parent directories up to the repository root, which contains the `.git` directory.
+// The path is empty when no project configuration file is found.
+func FindProjectConfig(chartPath string) (string, error) {
+	dir, err := filepath.Abs(chartPath)
+	if err != nil {
+		return "", err
+	}
+	for {
+		configPath := filepath.Join(dir, ProjectConfigFile)
+		if _, err := os.Stat(configPath); err == nil {
+			return configPath, nil
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return "", nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// LoadProjectConfig reads the project configuration file, unknown fields are an error. The values files and the
+// output file are relative to the directory of the project configuration file.
+func LoadProjectConfig(configPath string) (*ProjectConfig, error) {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &ProjectConfig{}
+	yamlDecoder := common.YamlNewDecoder(strings.NewReader(string(content)))
+	yamlDecoder.KnownFields(true)
+	if err := yamlDecoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse %s: %w", configPath, err)
+	}
+
+	configDir := filepath.Dir(configPath)
+	for idx, valuesFile := range config.ValuesFiles {
+		config.ValuesFiles[idx] = relativeToDir(configDir, valuesFile)
+	}
+	if config.OutputFile != "" {
+		config.OutputFile = relativeToDir(configDir, config.OutputFile)
+	}
+	if config.RenderPath != "" {
+		config.RenderPath = relativeToDir(configDir, config.RenderPath)
+	}
+	return config, nil
+}
+
+// relativeToDir returns the path joined to the directory, when the path is not absolute.
+func relativeToDir(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// polishDefaultSettings uses the defaults for the settings, which are not defined in the test suite.
+// The snapshot redactions of the defaults are merged with the redactions of the test suite.
+func (s *TestSuite) polishDefaultSettings(defaults SuiteDefaults) {
+	if defaults.Release != nil {
+		s.Release.Name = cmp.Or(s.Release.Name, defaults.Release.Name)
+		s.Release.Namespace = cmp.Or(s.Release.Namespace, defaults.Release.Namespace)
+		s.Release.Revision = cmp.Or(s.Release.Revision, defaults.Release.Revision)
+		if s.Release.IsUpgrade == nil {
+			isUpgrade := defaults.Release.IsUpgrade
+			s.Release.IsUpgrade = &isUpgrade
+		}
+	}
+
+	if defaults.Capabilities != nil {
+		s.Capabilities.MajorVersion = cmp.Or(s.Capabilities.MajorVersion, defaults.Capabilities.MajorVersion)
+		s.Capabilities.MinorVersion = cmp.Or(s.Capabilities.MinorVersion, defaults.Capabilities.MinorVersion)
+		if len(s.Capabilities.APIVersions) == 0 {
+			s.Capabilities.APIVersions = defaults.Capabilities.APIVersions
+		}
+	}
+
+	if defaults.PostRenderer != nil && s.PostRendererConfig.Cmd == "" {
+		s.PostRendererConfig = *defaults.PostRenderer
+	}
+
+	if defaults.Snapshot != nil {
+		redaction := *defaults.Snapshot
+		if s.Snapshot != nil {
+			redaction = redaction.Merge(*s.Snapshot)
+		}
+		s.Snapshot = &redaction
+	}
+}
@@ -0,0 +1,178 @@
+package unittest_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindProjectConfig(t *testing.T) {
+	repoPath := t.TempDir()
+	chartPath := filepath.Join(repoPath, "charts", "basic")
+	assert.NoError(t, os.MkdirAll(chartPath, 0755))
+	assert.NoError(t, os.Mkdir(filepath.Join(repoPath, ".git"), 0755))
+
+	// The repository root ends the search.
+	configPath, err := FindProjectConfig(chartPath)
+	assert.NoError(t, err)
+	assert.Empty(t, configPath)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(repoPath, ProjectConfigFile), []byte("strict: true\n"), 0644))
+	configPath, err = FindProjectConfig(chartPath)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(repoPath, ProjectConfigFile), configPath)
+
+	// The project configuration file of the chart is used before the one of the repository.
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, ProjectConfigFile), []byte("strict: false\n"), 0644))
+	configPath, err = FindProjectConfig(chartPath)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(chartPath, ProjectConfigFile), configPath)
+}
+
+func TestLoadProjectConfig(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, ProjectConfigFile)
+	assert.NoError(t, os.WriteFile(configPath, []byte(`testFiles:
+  - tests/**/*_test.yaml
+valuesFiles:
+  - values/ci.yaml
+  - /etc/values.yaml
+outputFile: results.xml
+strict: true
+release:
+  name: my-release
+capabilities:
+  majorVersion: "1"
+  minorVersion: "30"
+snapshot:
+  redact:
+    - metadata.annotations.checksum
+`), 0644))
+
+	config, err := LoadProjectConfig(configPath)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tests/**/*_test.yaml"}, config.TestFiles)
+	assert.Equal(t, []string{filepath.Join(dir, "values", "ci.yaml"), "/etc/values.yaml"}, config.ValuesFiles)
+	assert.Equal(t, filepath.Join(dir, "results.xml"), config.OutputFile)
+	assert.True(t, *config.Strict)
+	assert.Nil(t, config.CI)
+	assert.Equal(t, "my-release", config.Release.Name)
+	assert.Equal(t, "30", config.Capabilities.MinorVersion)
+	assert.Equal(t, []string{"metadata.annotations.checksum"}, config.Snapshot.Redact)
+
+	assert.NoError(t, os.WriteFile(configPath, []byte("stict: true\n"), 0644))
+	_, err = LoadProjectConfig(configPath)
+	assert.ErrorContains(t, err, "field stict not found")
+}
+
+func TestV3RunnerWithSuiteDefaults(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "basic")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS(testV3BasicChart)))
+	assert.NoError(t, os.RemoveAll(filepath.Join(chartPath, "tests")))
+	assert.NoError(t, os.Mkdir(filepath.Join(chartPath, "tests"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "service_test.yaml"), []byte(`suite: default release
+templates:
+  - service.yaml
+tests:
+  - it: should use the default release
+    asserts:
+      - equal:
+          path: metadata.name
+          value: my-release-basic
+---
+suite: suite release
+templates:
+  - service.yaml
+release:
+  name: suite-release
+tests:
+  - it: should use the release of the suite
+    asserts:
+      - equal:
+          path: metadata.name
+          value: suite-release-basic
+`), 0644))
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/*_test.yaml"},
+		SuiteDefaults: SuiteDefaults{
+			Release: &ReleaseDefaults{Name: "my-release"},
+		},
+	}
+	assert.True(t, runner.RunV3([]string{chartPath}), buffer.String())
+	assert.Contains(t, buffer.String(), "Test Suites: 2 passed, 2 total")
+}
+
+func TestV3RunnerWithSuiteDefaultsOverriddenBySuite(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "basic")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS(testV3BasicChart)))
+	assert.NoError(t, os.RemoveAll(filepath.Join(chartPath, "tests")))
+	assert.NoError(t, os.Mkdir(filepath.Join(chartPath, "tests"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "templates", "release.yaml"), []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: release
+data:
+  upgrade: {{ .Release.IsUpgrade | quote }}
+`), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "release_test.yaml"), []byte(`suite: default upgrade
+templates:
+  - release.yaml
+tests:
+  - it: should use the default upgrade
+    asserts:
+      - equal:
+          path: data.upgrade
+          value: "true"
+---
+suite: suite install
+templates:
+  - release.yaml
+release:
+  upgrade: false
+tests:
+  - it: should turn off the default upgrade
+    asserts:
+      - equal:
+          path: data.upgrade
+          value: "false"
+---
+suite: test install
+templates:
+  - release.yaml
+release:
+  upgrade: true
+tests:
+  - it: should use the upgrade of the suite
+    asserts:
+      - equal:
+          path: data.upgrade
+          value: "true"
+  - it: should turn off the upgrade of the suite
+    release:
+      upgrade: false
+    asserts:
+      - equal:
+          path: data.upgrade
+          value: "false"
+`), 0644))
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/*_test.yaml"},
+		SuiteDefaults: SuiteDefaults{
+			Release: &ReleaseDefaults{IsUpgrade: true},
+		},
+	}
+	assert.True(t, runner.RunV3([]string{chartPath}), buffer.String())
+	assert.Contains(t, buffer.String(), "Test Suites: 3 passed, 3 total")
+	assert.Contains(t, buffer.String(), "Tests:       4 passed, 4 total")
+}
@@ -0,0 +1,239 @@
+package unittest
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+)
+
+// renderedOutputFile the file which lists the files written to the directory of the rendered output of a test.
+// Only the listed files are replaced, removed or compared, the other files in the render path are left untouched.
+const renderedOutputFile = ".helm-unittest-rendered"
+
+// renderedFiles the content of the rendered files in the render path, by the path relative to the render path
+type renderedFiles map[string]string
+
+// readRenderedFiles reads the rendered files listed in the render path, nil when no rendered output is found.
+func readRenderedFiles(renderPath string) (renderedFiles, error) {
+	outputDirs, err := renderedOutputDirs(renderPath)
+	if err != nil || len(outputDirs) == 0 {
+		return nil, err
+	}
+	files := renderedFiles{}
+	for _, outputDir := range outputDirs {
+		listed, err := readRenderedOutputList(outputDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range listed {
+			content, err := os.ReadFile(filepath.Join(outputDir, file))
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			relPath, err := filepath.Rel(renderPath, filepath.Join(outputDir, file))
+			if err != nil {
+				return nil, err
+			}
+			files[filepath.ToSlash(relPath)] = string(content)
+		}
+	}
+	return files, nil
+}
+
+// renderedOutputDirs returns the directories of the rendered output of the tests in the render path.
+func renderedOutputDirs(renderPath string) ([]string, error) {
+	var outputDirs []string
+	err := filepath.WalkDir(renderPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path == renderPath {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if !entry.IsDir() && entry.Name() == renderedOutputFile {
+			outputDirs = append(outputDirs, filepath.Dir(path))
+		}
+		return nil
+	})
+	return outputDirs, err
+}
+
+// readRenderedOutputList returns the files listed in the directory of the rendered output of a test,
+// relative to the directory. Files outside the directory are not listed.
+func readRenderedOutputList(outputDir string) ([]string, error) {
+	content, err := os.ReadFile(filepath.Join(outputDir, renderedOutputFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, line := range strings.Split(string(content), "\n") {
+		if file := filepath.FromSlash(line); line != "" && filepath.IsLocal(file) {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// writeRenderedOutputList lists the written files in the directory of the rendered output of a test.
+func writeRenderedOutputList(outputDir string, files []string) error {
+	sort.Strings(files)
+	content := strings.Join(files, "\n") + "\n"
+	return os.WriteFile(filepath.Join(outputDir, renderedOutputFile), []byte(content), 0644)
+}
+
+// removeRenderedOutput removes the listed files of the rendered output of a test, and the directories which
+// become empty up to the root.
+func removeRenderedOutput(outputDir, root string) error {
+	listed, err := readRenderedOutputList(outputDir)
+	if err != nil {
+		return err
+	}
+	for _, file := range append(listed, renderedOutputFile) {
+		path := filepath.Join(outputDir, file)
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		removeEmptyDirs(filepath.Dir(path), root)
+	}
+	return nil
+}
+
+// removeEmptyDirs removes the directory and its parents below the root, as long as they are empty.
+func removeEmptyDirs(dir, root string) {
+	for {
+		relPath, err := filepath.Rel(root, dir)
+		if err != nil || relPath == "." || !filepath.IsLocal(relPath) {
+			return
+		}
+		if os.Remove(dir) != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// diffRenderedFiles returns the sorted paths of the changed, added and removed files of the current render.
+func diffRenderedFiles(previous, current renderedFiles) (changed, added, removed []string) {
+	for path, content := range current {
+		previousContent, found := previous[path]
+		if !found {
+			added = append(added, path)
+		} else if previousContent != content {
+			changed = append(changed, path)
+		}
+	}
+	for path := range previous {
+		if _, found := current[path]; !found {
+			removed = append(removed, path)
+		}
+	}
+	sort.Strings(changed)
+	sort.Strings(added)
+	sort.Strings(removed)
+	return changed, added, removed
+}
+
+// clearChartRenderPath removes the rendered output of the previous run of the chart, so the output of removed tests
+// is not kept, and returns the directory of the rendered output of the chart, `<render-path>/<chart>`.
+// Only the files written by the previous run are removed.
+func (tr *TestRunner) clearChartRenderPath(chartName string, chartRenderDirs map[string]bool) (string, error) {
+	if tr.RenderPath == "" {
+		return "", nil
+	}
+	chartDir := common.UniqueName(cmp.Or(common.FileNameOf(chartName), "chart"), chartRenderDirs)
+	chartRenderPath := filepath.Join(tr.RenderPath, chartDir)
+
+	outputDirs, err := renderedOutputDirs(chartRenderPath)
+	if err != nil {
+		return chartRenderPath, err
+	}
+	for _, outputDir := range outputDirs {
+		if err := removeRenderedOutput(outputDir, tr.RenderPath); err != nil {
+			return chartRenderPath, err
+		}
+	}
+	return chartRenderPath, nil
+}
+
+// checkRenderPath refuses the render path when it is, or contains, the directory of the chart or a test suite,
+// as the rendered output would be written between the files of the chart.
+func (tr *TestRunner) checkRenderPath(chartPath string, suites []*TestSuite) error {
+	if tr.RenderPath == "" {
+		return nil
+	}
+	renderPath, err := filepath.Abs(tr.RenderPath)
+	if err != nil {
+		return err
+	}
+
+	dirs := []string{chartPath}
+	for _, suite := range suites {
+		dirs = append(dirs, filepath.Dir(suite.definitionFile))
+	}
+	for _, dir := range dirs {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+		if relPath, err := filepath.Rel(renderPath, absDir); err == nil && filepath.IsLocal(relPath) {
+			return fmt.Errorf("render path %s contains the chart or test suite directory %s, use a separate directory", tr.RenderPath, dir)
+		}
+	}
+	return nil
+}
+
+// readPreviousRender reads the rendered files of the previous run, before they are replaced by the test run.
+func (tr *TestRunner) readPreviousRender() renderedFiles {
+	if !tr.RenderDiff || tr.RenderPath == "" {
+		return nil
+	}
+	previous, err := readRenderedFiles(tr.RenderPath)
+	if err != nil {
+		tr.printErroredChartHeader(err)
+	}
+	return previous
+}
+
+// printRenderDiff print the rendered files which changed since the previous run in footer
+func (tr *TestRunner) printRenderDiff(previous renderedFiles) {
+	if !tr.RenderDiff || tr.RenderPath == "" {
+		return
+	}
+	if previous == nil {
+		tr.Printer.Println(fmt.Sprintf(`
+Render Diff: no previous render in %s.`, tr.RenderPath), 0)
+		return
+	}
+	current, err := readRenderedFiles(tr.RenderPath)
+	if err != nil {
+		tr.printErroredChartHeader(err)
+		return
+	}
+
+	changed, added, removed := diffRenderedFiles(previous, current)
+	tr.Printer.Println(fmt.Sprintf(`
+Render Diff: %d changed, %d added and %d removed file in %s.`,
+		len(changed), len(added), len(removed), tr.RenderPath), 0)
+	for _, path := range changed {
+		tr.Printer.Println(tr.Printer.Warning("changed: %s", path), 1)
+	}
+	for _, path := range added {
+		tr.Printer.Println(tr.Printer.Success("added:   %s", path), 1)
+	}
+	for _, path := range removed {
+		tr.Printer.Println(tr.Printer.Danger("removed: %s", path), 1)
+	}
+}
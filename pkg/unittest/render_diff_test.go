@@ -0,0 +1,191 @@
+package unittest_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest"
+)
+
+func writeRenderDiffSuite(t *testing.T, chartPath, tag string) {
+	writeRenderDiffSuiteFile(t, chartPath, tag, `
+  - it: should use the defaults
+    asserts:
+      - isKind:
+          of: Deployment
+        template: deployment.yaml
+        documentIndex: 0
+`)
+}
+
+func writeRenderDiffSuiteFile(t *testing.T, chartPath, tag, otherTests string) {
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "deployment_test.yaml"), []byte(`suite: render diff
+templates:
+  - configmap.yaml
+  - deployment.yaml
+tests:
+  - it: should set the image
+    set:
+      image.tag: `+tag+`
+    asserts:
+      - isKind:
+          of: Deployment
+        template: deployment.yaml
+        documentIndex: 0
+`+otherTests), 0644))
+}
+
+func TestV3RunnerWithRenderDiff(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "basic")
+	renderPath := filepath.Join(t.TempDir(), "render")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS(testV3BasicChart)))
+	assert.NoError(t, os.RemoveAll(filepath.Join(chartPath, "tests")))
+	assert.NoError(t, os.Mkdir(filepath.Join(chartPath, "tests"), 0755))
+	writeRenderDiffSuite(t, chartPath, "first")
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:    printer.NewPrinter(buffer, nil),
+		TestFiles:  []string{"tests/*_test.yaml"},
+		RenderPath: renderPath,
+		RenderDiff: true,
+	}
+	assert.True(t, runner.RunV3([]string{chartPath}), buffer.String())
+	assert.Contains(t, buffer.String(), "Render Diff: no previous render in "+renderPath)
+	assert.FileExists(t, filepath.Join(renderPath, "basic", "tests-deployment_test.yaml", "render-diff", "should-set-the-image", "basic", "templates", "deployment.yaml"))
+	assert.FileExists(t, filepath.Join(renderPath, "basic", "tests-deployment_test.yaml", "render-diff", "should-use-the-defaults", "basic", "templates", "deployment.yaml"))
+
+	writeRenderDiffSuite(t, chartPath, "second")
+	buffer.Reset()
+	runner = TestRunner{
+		Printer:    printer.NewPrinter(buffer, nil),
+		TestFiles:  []string{"tests/*_test.yaml"},
+		RenderPath: renderPath,
+		RenderDiff: true,
+	}
+	assert.True(t, runner.RunV3([]string{chartPath}), buffer.String())
+	assert.Contains(t, buffer.String(), "Render Diff: 1 changed, 0 added and 0 removed file in "+renderPath)
+	assert.Contains(t, buffer.String(), "changed: basic/tests-deployment_test.yaml/render-diff/should-set-the-image/basic/templates/deployment.yaml")
+	assert.NotContains(t, buffer.String(), "should-use-the-defaults/basic/templates/deployment.yaml")
+}
+
+func TestV3RunnerWithRenderDiffOfRemovedTest(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "basic")
+	renderPath := filepath.Join(t.TempDir(), "render")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS(testV3BasicChart)))
+	assert.NoError(t, os.RemoveAll(filepath.Join(chartPath, "tests")))
+	assert.NoError(t, os.Mkdir(filepath.Join(chartPath, "tests"), 0755))
+	writeRenderDiffSuite(t, chartPath, "first")
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:    printer.NewPrinter(buffer, nil),
+		TestFiles:  []string{"tests/*_test.yaml"},
+		RenderPath: renderPath,
+		RenderDiff: true,
+	}
+	assert.True(t, runner.RunV3([]string{chartPath}), buffer.String())
+
+	writeRenderDiffSuiteFile(t, chartPath, "first", "")
+	buffer.Reset()
+	runner = TestRunner{
+		Printer:    printer.NewPrinter(buffer, nil),
+		TestFiles:  []string{"tests/*_test.yaml"},
+		RenderPath: renderPath,
+		RenderDiff: true,
+	}
+	assert.True(t, runner.RunV3([]string{chartPath}), buffer.String())
+	assert.Contains(t, buffer.String(), "Render Diff: 0 changed, 0 added and 2 removed file in "+renderPath)
+	assert.Contains(t, buffer.String(), "removed: basic/tests-deployment_test.yaml/render-diff/should-use-the-defaults/basic/templates/deployment.yaml")
+	assert.NoDirExists(t, filepath.Join(renderPath, "basic", "tests-deployment_test.yaml", "render-diff", "should-use-the-defaults"))
+}
+
+func TestV3RunnerWithRenderPathOfSuitesWithTheSameName(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "basic")
+	renderPath := filepath.Join(t.TempDir(), "render")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS(testV3BasicChart)))
+	assert.NoError(t, os.RemoveAll(filepath.Join(chartPath, "tests")))
+	assert.NoError(t, os.Mkdir(filepath.Join(chartPath, "tests"), 0755))
+	writeRenderDiffSuite(t, chartPath, "first")
+	suite, err := os.ReadFile(filepath.Join(chartPath, "tests", "deployment_test.yaml"))
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "other_test.yaml"), suite, 0644))
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:    printer.NewPrinter(buffer, nil),
+		TestFiles:  []string{"tests/*_test.yaml"},
+		RenderPath: renderPath,
+	}
+	assert.True(t, runner.RunV3([]string{chartPath}), buffer.String())
+	assert.FileExists(t, filepath.Join(renderPath, "basic", "tests-deployment_test.yaml", "render-diff", "should-set-the-image", "basic", "templates", "deployment.yaml"))
+	assert.FileExists(t, filepath.Join(renderPath, "basic", "tests-other_test.yaml", "render-diff", "should-set-the-image", "basic", "templates", "deployment.yaml"))
+}
+
+func TestV3RunnerWithRenderPathOfTheParentOfTheChart(t *testing.T) {
+	parentPath := t.TempDir()
+	chartPath := filepath.Join(parentPath, "basic")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS(testV3BasicChart)))
+	assert.NoError(t, os.RemoveAll(filepath.Join(chartPath, "tests")))
+	assert.NoError(t, os.Mkdir(filepath.Join(chartPath, "tests"), 0755))
+	writeRenderDiffSuite(t, chartPath, "first")
+	t.Chdir(parentPath)
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:    printer.NewPrinter(buffer, nil),
+		TestFiles:  []string{"tests/*_test.yaml"},
+		RenderPath: ".",
+	}
+	assert.False(t, runner.RunV3([]string{"basic"}), buffer.String())
+	assert.Contains(t, buffer.String(), "render path . contains the chart or test suite directory basic")
+	assert.FileExists(t, filepath.Join(chartPath, "Chart.yaml"))
+	assert.FileExists(t, filepath.Join(chartPath, "templates", "deployment.yaml"))
+	assert.FileExists(t, filepath.Join(chartPath, "tests", "deployment_test.yaml"))
+}
+
+func TestV3RunnerWithRenderPathKeepsOtherFiles(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "basic")
+	renderPath := filepath.Join(t.TempDir(), "render")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS(testV3BasicChart)))
+	assert.NoError(t, os.RemoveAll(filepath.Join(chartPath, "tests")))
+	assert.NoError(t, os.Mkdir(filepath.Join(chartPath, "tests"), 0755))
+	writeRenderDiffSuite(t, chartPath, "first")
+	otherFiles := []string{
+		filepath.Join(renderPath, "basic", "notes.txt"),
+		filepath.Join(renderPath, "basic", "tests-deployment_test.yaml", "render-diff", "should-use-the-defaults", "notes.txt"),
+	}
+	for _, file := range otherFiles {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+		assert.NoError(t, os.WriteFile(file, []byte("keep"), 0644))
+	}
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:    printer.NewPrinter(buffer, nil),
+		TestFiles:  []string{"tests/*_test.yaml"},
+		RenderPath: renderPath,
+	}
+	assert.True(t, runner.RunV3([]string{chartPath}), buffer.String())
+
+	writeRenderDiffSuiteFile(t, chartPath, "first", "")
+	buffer.Reset()
+	runner = TestRunner{
+		Printer:    printer.NewPrinter(buffer, nil),
+		TestFiles:  []string{"tests/*_test.yaml"},
+		RenderPath: renderPath,
+		RenderDiff: true,
+	}
+	assert.True(t, runner.RunV3([]string{chartPath}), buffer.String())
+	assert.Contains(t, buffer.String(), "Render Diff: 0 changed, 0 added and 2 removed file in "+renderPath)
+	assert.NotContains(t, buffer.String(), "notes.txt")
+	for _, file := range otherFiles {
+		assert.FileExists(t, file)
+	}
+	assert.NoDirExists(t, filepath.Join(renderPath, "basic", "tests-deployment_test.yaml", "render-diff", "should-use-the-defaults", "basic"))
+}
@@ -16,6 +16,8 @@ type AssertionResult struct {
 	AssertType string
 	Not        bool
 	CustomInfo string
+	// Source the test suite file and the line of the assertion, empty when unknown
+	Source string
 }
 
 func (ar AssertionResult) print(printer *printer.Printer, verbosity int) {
@@ -24,6 +26,9 @@ func (ar AssertionResult) print(printer *printer.Printer, verbosity int) {
 	}
 
 	printer.Println(printer.Danger("%s", ar.getTitle()), 2)
+	if ar.Source != "" {
+		printer.Println(fmt.Sprintf("Source:\t%s", ar.Source), 3)
+	}
 	for _, infoLine := range ar.FailInfo {
 		printer.Println(infoLine, 3)
 	}
@@ -47,6 +52,9 @@ func (ar AssertionResult) getTitle() string {
 // ToString writing the object to a customized formatted string.
 func (ar AssertionResult) stringify() string {
 	content := fmt.Sprintf("\t\t %s \n", ar.getTitle())
+	if ar.Source != "" {
+		content += fmt.Sprintf("\t\t\t Source:\t%s \n", ar.Source)
+	}
 
 	for _, infoLine := range ar.FailInfo {
 		content += fmt.Sprintf("\t\t\t %s \n", infoLine)
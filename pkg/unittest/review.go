@@ -0,0 +1,130 @@
+package unittest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// ReviewDecision the decision of the review of a changed or new snapshot
+type ReviewDecision int
+
+const (
+	// ReviewSkip leaves the cached snapshot untouched, to review the snapshot another time
+	ReviewSkip ReviewDecision = iota
+	// ReviewAccept stores the new snapshot
+	ReviewAccept
+	// ReviewReject discards the new snapshot and fails the review, as the rendered output is wrong
+	ReviewReject
+)
+
+// SnapshotReviewer decides which changed and new snapshots are stored
+type SnapshotReviewer interface {
+	Review(suite string, pending snapshot.PendingSnapshot) ReviewDecision
+}
+
+// reviewCounting stores counting numbers of the reviewed snapshots
+type reviewCounting struct {
+	accepted uint
+	rejected uint
+	skipped  uint
+}
+
+// PromptReviewer shows the diff of each snapshot and asks to accept, reject or skip the snapshot
+type PromptReviewer struct {
+	Printer  *printer.Printer
+	LineDiff bool
+	input    *bufio.Reader
+}
+
+// NewPromptReviewer returns a PromptReviewer reading the decisions from input.
+func NewPromptReviewer(input io.Reader, printer *printer.Printer, lineDiff bool) *PromptReviewer {
+	return &PromptReviewer{
+		Printer:  printer,
+		LineDiff: lineDiff,
+		input:    bufio.NewReader(input),
+	}
+}
+
+// Review implement SnapshotReviewer, the remaining snapshots are skipped when the input ends.
+func (r *PromptReviewer) Review(suite string, pending snapshot.PendingSnapshot) ReviewDecision {
+	state := "changed"
+	if pending.IsNew {
+		state = "new"
+	}
+	r.Printer.Println(fmt.Sprintf("%s %s > %s > %s",
+		r.Printer.Highlight("%s", "Snapshot:"), suite, pending.Test, pending.Key), 0)
+	r.Printer.Println(r.Printer.Faint("(%s)", state), 1)
+	for _, line := range strings.Split(SnapshotReviewDiff(pending, r.LineDiff), "\n") {
+		r.Printer.Println(line, 1)
+	}
+
+	for {
+		r.Printer.Println("Accept (a), reject (r) or skip (s)? ", 0)
+		answer, err := r.input.ReadString('\n')
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "a", "accept":
+			return ReviewAccept
+		case "r", "reject":
+			return ReviewReject
+		case "s", "skip":
+			return ReviewSkip
+		}
+		if err != nil {
+			return ReviewSkip
+		}
+	}
+}
+
+// SnapshotReviewDiff returns the changed paths of the snapshot, or the changed lines when lineDiff is set or
+// the changes can not be expressed as paths. New snapshots are shown as added lines.
+func SnapshotReviewDiff(pending snapshot.PendingSnapshot, lineDiff bool) string {
+	if pending.IsNew {
+		lines := strings.Split(strings.TrimSuffix(pending.NewSnapshot, "\n"), "\n")
+		for idx, line := range lines {
+			lines[idx] = "+" + line
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	if !lineDiff {
+		if changes, err := snapshot.StructuralDiff(pending.CachedSnapshot, pending.NewSnapshot); err == nil {
+			return strings.Join(changes, "\n")
+		}
+	}
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(strings.TrimSuffix(pending.CachedSnapshot, "\n")),
+		B:        difflib.SplitLines(strings.TrimSuffix(pending.NewSnapshot, "\n")),
+		FromFile: "Cached",
+		ToFile:   "New",
+		Context:  1,
+	})
+	return strings.TrimSuffix(diff, "\n")
+}
+
+// reviewSnapshotsOfSuite asks the reviewer to decide on the changed and new snapshots of the suite,
+// only the accepted snapshots are stored. Returns false when a snapshot is rejected.
+func (tr *TestRunner) reviewSnapshotsOfSuite(suite *TestSuite, cache *snapshot.Cache) bool {
+	if tr.Reviewer == nil {
+		return true
+	}
+	passed := true
+	for _, pending := range cache.Pending() {
+		switch tr.Reviewer.Review(suite.Name, pending) {
+		case ReviewAccept:
+			cache.Accept(pending)
+			tr.reviewCounting.accepted++
+		case ReviewReject:
+			tr.reviewCounting.rejected++
+			passed = false
+		default:
+			tr.reviewCounting.skipped++
+		}
+	}
+	return passed
+}
@@ -0,0 +1,117 @@
+package unittest_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+// sequenceReviewer decides on the snapshots in the order of the decisions, and skips the remaining snapshots.
+type sequenceReviewer struct {
+	decisions []ReviewDecision
+	reviewed  []snapshot.PendingSnapshot
+}
+
+func (r *sequenceReviewer) Review(_ string, pending snapshot.PendingSnapshot) ReviewDecision {
+	r.reviewed = append(r.reviewed, pending)
+	if len(r.decisions) == 0 {
+		return ReviewSkip
+	}
+	decision := r.decisions[0]
+	r.decisions = r.decisions[1:]
+	return decision
+}
+
+func TestPromptReviewerDecisions(t *testing.T) {
+	pending := snapshot.PendingSnapshot{Test: "test", Key: "labels", CachedSnapshot: "app: a\n", NewSnapshot: "app: b\n"}
+	for input, expected := range map[string]ReviewDecision{
+		"a\n":         ReviewAccept,
+		"accept\n":    ReviewAccept,
+		"R\n":         ReviewReject,
+		"s\n":         ReviewSkip,
+		"x\nreject\n": ReviewReject,
+		"":            ReviewSkip,
+	} {
+		buffer := new(bytes.Buffer)
+		reviewer := NewPromptReviewer(strings.NewReader(input), printer.NewPrinter(buffer, nil), false)
+
+		assert.Equal(t, expected, reviewer.Review("suite", pending), input)
+		assert.Contains(t, buffer.String(), "Snapshot: suite > test > labels")
+		assert.Contains(t, buffer.String(), `app: "a" -> "b"`)
+	}
+}
+
+func TestSnapshotReviewDiff(t *testing.T) {
+	changed := snapshot.PendingSnapshot{CachedSnapshot: "app: a\nteam: x\n", NewSnapshot: "app: b\nteam: x\n"}
+	assert.Equal(t, `app: "a" -> "b"`, SnapshotReviewDiff(changed, false))
+	assert.Equal(t, "--- Cached\n+++ New\n@@ -1,2 +1,2 @@\n-app: a\n+app: b\n team: x", SnapshotReviewDiff(changed, true))
+
+	raw := snapshot.PendingSnapshot{CachedSnapshot: "a: [\n", NewSnapshot: "b\n"}
+	assert.Equal(t, "--- Cached\n+++ New\n@@ -1 +1 @@\n-a: [\n+b", SnapshotReviewDiff(raw, false))
+
+	created := snapshot.PendingSnapshot{NewSnapshot: "app: a\nteam: x\n", IsNew: true}
+	assert.Equal(t, "+app: a\n+team: x", SnapshotReviewDiff(created, false))
+}
+
+func TestV3RunnerWith_Fixture_Chart_WithSnapshot_Review(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "chart-snapshot")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS("testdata/chart-snapshot")))
+	snapshotFile := filepath.Join(chartPath, "tests", "success", "__snapshot__", "network-snapshot_test.yaml.snap")
+	content, err := os.ReadFile(snapshotFile)
+	assert.NoError(t, err)
+	changed := strings.ReplaceAll(string(content), "app: test-cluster", "app: other-cluster")
+	assert.NoError(t, os.WriteFile(snapshotFile, []byte(changed), 0644))
+
+	buffer := new(bytes.Buffer)
+	reviewer := &sequenceReviewer{decisions: []ReviewDecision{ReviewAccept, ReviewReject}}
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/success/*_test.yaml"},
+		Strict:    true,
+		Reviewer:  reviewer,
+	}
+	assert.False(t, runner.RunV3([]string{chartPath}))
+	assert.Len(t, reviewer.reviewed, 2)
+	assert.Contains(t, buffer.String(), "Review Summary: 1 snapshot accepted, 1 rejected and 0 skipped.")
+	assert.Contains(t, buffer.String(), "The rejected snapshots fail the review, fix the chart or the tests.")
+
+	// Only the accepted snapshot is stored, by the name of the snapshot.
+	stored, err := os.ReadFile(snapshotFile)
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Count(changed, "other-cluster")-1, strings.Count(string(stored), "other-cluster"))
+	assert.Contains(t, string(stored), "  snapshot/templates/network.yaml NetworkPolicy/: |\n    apiVersion: networking.k8s.io/v1\n    kind: NetworkPolicy\n    metadata:\n      labels:\n        app: test-cluster\n")
+}
+
+func TestV3RunnerWith_Fixture_Chart_WithSnapshot_ReviewSkipped(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "chart-snapshot")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS("testdata/chart-snapshot")))
+	snapshotFile := filepath.Join(chartPath, "tests", "success", "__snapshot__", "network-snapshot_test.yaml.snap")
+	content, err := os.ReadFile(snapshotFile)
+	assert.NoError(t, err)
+	changed := strings.ReplaceAll(string(content), "app: test-cluster", "app: other-cluster")
+	assert.NoError(t, os.WriteFile(snapshotFile, []byte(changed), 0644))
+
+	buffer := new(bytes.Buffer)
+	reviewer := &sequenceReviewer{decisions: []ReviewDecision{ReviewAccept}}
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/success/*_test.yaml"},
+		Strict:    true,
+		Reviewer:  reviewer,
+	}
+	// The skipped snapshot does not fail the review, but is not stored.
+	assert.True(t, runner.RunV3([]string{chartPath}), buffer.String())
+	assert.Contains(t, buffer.String(), "Review Summary: 1 snapshot accepted, 0 rejected and 1 skipped.")
+	assert.Contains(t, buffer.String(), "The skipped snapshots are not stored, review them again.")
+
+	stored, err := os.ReadFile(snapshotFile)
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Count(changed, "other-cluster")-1, strings.Count(string(stored), "other-cluster"))
+}
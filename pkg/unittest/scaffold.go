@@ -0,0 +1,271 @@
+package unittest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	"gopkg.in/yaml.v3"
+	v3chart "helm.sh/helm/v3/pkg/chart"
+	v3loader "helm.sh/helm/v3/pkg/chart/loader"
+)
+
+// scaffoldTestsDir the directory of the scaffolded test suites, relative to the chart
+const scaffoldTestsDir = "tests"
+
+// scaffoldHeader the comment on top of the scaffolded test suites
+const scaffoldHeader = "# Scaffolded from the output rendered with the default values, review the assertions before committing.\n"
+
+// ScaffoldOptions the options to scaffold the test suites of a chart
+type ScaffoldOptions struct {
+	// WithSnapshot adds a matchSnapshot assertion to the tests
+	WithSnapshot bool
+	// Overwrite replaces the existing test suite files, instead of skipping the templates
+	Overwrite bool
+}
+
+// ScaffoldResult the test suite files written and skipped by the scaffold
+type ScaffoldResult struct {
+	Written []string
+	Skipped []string
+}
+
+// scaffoldSuite the test suite written by the scaffold
+type scaffoldSuite struct {
+	Suite     string         `yaml:"suite"`
+	Templates []string       `yaml:"templates,omitempty"`
+	Tests     []scaffoldTest `yaml:"tests"`
+}
+
+type scaffoldTest struct {
+	It       string              `yaml:"it"`
+	Template string              `yaml:"template,omitempty"`
+	Asserts  []scaffoldAssertion `yaml:"asserts"`
+}
+
+type scaffoldAssertion struct {
+	Type          string
+	Value         map[string]interface{}
+	DocumentIndex *int
+}
+
+// MarshalYAML writes the assertion followed by the documentIndex
+func (a scaffoldAssertion) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	entries := []interface{}{a.Type, a.Value}
+	if a.DocumentIndex != nil {
+		entries = append(entries, "documentIndex", *a.DocumentIndex)
+	}
+	for _, entry := range entries {
+		entryNode := &yaml.Node{}
+		if err := entryNode.Encode(entry); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, entryNode)
+	}
+	return node, nil
+}
+
+// ScaffoldV3 renders the chart with the default values, and writes a test suite for each template of the chart
+// to the tests directory of the chart. The assertions check the kind, apiVersion, name, replicas, images and ports
+// of the rendered documents.
+func ScaffoldV3(chartPath string, options ScaffoldOptions) (*ScaffoldResult, error) {
+	chart, err := v3loader.Load(chartPath)
+	if err != nil {
+		return nil, err
+	}
+	testsPath := filepath.Join(chartPath, scaffoldTestsDir)
+
+	manifestsOfFiles, err := scaffoldRender(chart, testsPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render chart %s: %w", chart.Name(), err)
+	}
+
+	templatesPrefix := chart.Name() + "/templates/"
+	files := make([]string, 0, len(manifestsOfFiles))
+	for file := range manifestsOfFiles {
+		extension := filepath.Ext(file)
+		if strings.HasPrefix(file, templatesPrefix) && (extension == ".yaml" || extension == ".yml") {
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+
+	result := &ScaffoldResult{}
+	for _, file := range files {
+		template := strings.TrimPrefix(file, templatesPrefix)
+		name := strings.TrimSuffix(template, filepath.Ext(template))
+		suiteFile := filepath.Join(testsPath, strings.ReplaceAll(name, "/", "-")+"_test.yaml")
+		if _, err := os.Stat(suiteFile); err == nil && !options.Overwrite {
+			result.Skipped = append(result.Skipped, suiteFile)
+			continue
+		}
+
+		suite := scaffoldSuite{Suite: "test " + name, Templates: []string{template}}
+		test := scaffoldTest{It: "should render with the default values"}
+		if _, err := scaffoldRender(chart, testsPath, suite.Templates); err != nil {
+			// The template includes other templates, so the test suite renders all templates.
+			suite.Templates = nil
+			test.Template = template
+		}
+		test.Asserts = scaffoldAssertionsOfDocuments(manifestsOfFiles[file], options)
+		suite.Tests = []scaffoldTest{test}
+
+		content, err := scaffoldSuiteContent(suite)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scaffold %s: %w", template, err)
+		}
+		if err := os.MkdirAll(testsPath, 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(suiteFile, content, 0644); err != nil {
+			return nil, err
+		}
+		result.Written = append(result.Written, suiteFile)
+	}
+	return result, nil
+}
+
+// scaffoldRender renders the templates of the chart the same way as a test without assertions,
+// all templates are rendered when templates is empty.
+func scaffoldRender(chart *v3chart.Chart, testsPath string, templates []string) (map[string][]common.K8sManifest, error) {
+	job := &TestJob{
+		Name:                     "scaffold",
+		chartRoute:               chart.Name(),
+		definitionFile:           filepath.Join(testsPath, "scaffold_test.yaml"),
+		defaultTemplatesToAssert: templates,
+		requireRenderSuccess:     true,
+	}
+	job.WithConfig(*NewTestConfig(chart, &snapshot.Cache{}))
+	userValues, err := job.getUserValues()
+	if err != nil {
+		return nil, err
+	}
+	outputOfFiles, _, err := job.renderV3Chart([]byte(userValues))
+	if err != nil {
+		return nil, err
+	}
+	return job.parseManifestsFromOutputOfFiles(outputOfFiles)
+}
+
+// scaffoldAssertionsOfDocuments returns the assertions of the rendered documents of a template.
+func scaffoldAssertionsOfDocuments(manifests []common.K8sManifest, options ScaffoldOptions) []scaffoldAssertion {
+	asserts := []scaffoldAssertion{{Type: "hasDocuments", Value: map[string]interface{}{"count": len(manifests)}}}
+	for idx, manifest := range manifests {
+		var documentIndex *int
+		if len(manifests) > 1 {
+			documentIndex = &idx
+		}
+		for _, assertion := range scaffoldAssertionsOf(manifest) {
+			assertion.DocumentIndex = documentIndex
+			asserts = append(asserts, assertion)
+		}
+	}
+	if options.WithSnapshot && len(manifests) > 0 {
+		asserts = append(asserts, scaffoldAssertion{Type: "matchSnapshot", Value: map[string]interface{}{}})
+	}
+	return asserts
+}
+
+// scaffoldSuiteContent returns the content of the test suite file.
+func scaffoldSuiteContent(suite scaffoldSuite) ([]byte, error) {
+	byteBuffer := bytes.NewBufferString(scaffoldHeader)
+	yamlEncoder := common.YamlNewEncoder(byteBuffer)
+	yamlEncoder.SetIndent(common.YAMLINDENTION)
+	if err := yamlEncoder.Encode(suite); err != nil {
+		return nil, err
+	}
+	if err := yamlEncoder.Close(); err != nil {
+		return nil, err
+	}
+	return byteBuffer.Bytes(), nil
+}
+
+// scaffoldAssertionsOf returns the assertions of the key fields of the document.
+func scaffoldAssertionsOf(manifest common.K8sManifest) []scaffoldAssertion {
+	assertions := make([]scaffoldAssertion, 0)
+	if kind, ok := manifest["kind"].(string); ok {
+		assertions = append(assertions, scaffoldAssertion{Type: "isKind", Value: map[string]interface{}{"of": kind}})
+	}
+	if apiVersion, ok := manifest["apiVersion"].(string); ok {
+		assertions = append(assertions, scaffoldAssertion{Type: "isAPIVersion", Value: map[string]interface{}{"of": apiVersion}})
+	}
+
+	equal := func(path string, value interface{}) {
+		assertions = append(assertions, scaffoldAssertion{Type: "equal", Value: map[string]interface{}{"path": path, "value": value}})
+	}
+	if name, err := scaffoldValue(manifest, "metadata", "name"); err == nil {
+		equal("metadata.name", name)
+	}
+	if replicas, err := scaffoldValue(manifest, "spec", "replicas"); err == nil {
+		equal("spec.replicas", replicas)
+	}
+	if manifest["kind"] == "Service" {
+		if ports, err := scaffoldValue(manifest, "spec", "ports"); err == nil {
+			equal("spec.ports", ports)
+		}
+	}
+
+	podSpecPath := scaffoldPodSpecPath(manifest)
+	if podSpecPath == nil {
+		return assertions
+	}
+	for _, containersKey := range []string{"initContainers", "containers"} {
+		containers, err := scaffoldValue(manifest, append(podSpecPath, containersKey)...)
+		if err != nil {
+			continue
+		}
+		containerList, _ := containers.([]interface{})
+		for idx := range containerList {
+			containerPath := fmt.Sprintf("%s.%s[%d]", strings.Join(podSpecPath, "."), containersKey, idx)
+			if image, err := scaffoldValue(containerList[idx], "image"); err == nil {
+				equal(containerPath+".image", image)
+			}
+			if ports, err := scaffoldValue(containerList[idx], "ports"); err == nil {
+				equal(containerPath+".ports", ports)
+			}
+		}
+	}
+	return assertions
+}
+
+// scaffoldPodSpecPath returns the path of the pod spec of workloads, nil when the document has no pod spec.
+func scaffoldPodSpecPath(manifest common.K8sManifest) []string {
+	for _, path := range [][]string{
+		{"spec", "template", "spec"},
+		{"spec", "jobTemplate", "spec", "template", "spec"},
+	} {
+		if _, err := scaffoldValue(manifest, path...); err == nil {
+			return path
+		}
+	}
+	if manifest["kind"] == "Pod" {
+		return []string{"spec"}
+	}
+	return nil
+}
+
+// scaffoldValue returns the value of the keys in the document or the value.
+func scaffoldValue(value interface{}, keys ...string) (interface{}, error) {
+	for _, key := range keys {
+		var mapping map[string]interface{}
+		switch typed := value.(type) {
+		case common.K8sManifest:
+			mapping = typed
+		case map[string]interface{}:
+			mapping = typed
+		}
+		next, ok := mapping[key]
+		if !ok || next == nil {
+			return nil, errors.New("not found")
+		}
+		value = next
+	}
+	return value, nil
+}
@@ -0,0 +1,72 @@
+package unittest_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScaffoldV3(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "basic")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS(testV3BasicChart)))
+	assert.NoError(t, os.RemoveAll(filepath.Join(chartPath, "tests")))
+
+	result, err := ScaffoldV3(chartPath, ScaffoldOptions{WithSnapshot: true})
+	assert.NoError(t, err)
+	assert.Len(t, result.Written, 12)
+	assert.Empty(t, result.Skipped)
+
+	content, err := os.ReadFile(filepath.Join(chartPath, "tests", "service_test.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, `# Scaffolded from the output rendered with the default values, review the assertions before committing.
+suite: test service
+templates:
+  - service.yaml
+tests:
+  - it: should render with the default values
+    asserts:
+      - hasDocuments:
+          count: 1
+      - isKind:
+          of: Service
+      - isAPIVersion:
+          of: v1
+      - equal:
+          path: metadata.name
+          value: RELEASE-NAME-basic
+      - equal:
+          path: spec.ports
+          value:
+            - name: nginx
+              port: 80
+              protocol: TCP
+              targetPort: 80
+      - matchSnapshot: {}
+`, string(content))
+
+	// The deployment includes the configmap, so the test suite renders all templates.
+	content, err = os.ReadFile(filepath.Join(chartPath, "tests", "deployment_test.yaml"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "suite: test deployment\ntests:\n  - it: should render with the default values\n    template: deployment.yaml\n")
+	assert.Contains(t, string(content), "      - equal:\n          path: spec.template.spec.containers[0].image\n          value: nginx:stable\n        documentIndex: 0\n")
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/*_test.yaml"},
+		Strict:    true,
+	}
+	assert.True(t, runner.RunV3([]string{chartPath}), buffer.String())
+	assert.Contains(t, buffer.String(), "Test Suites: 12 passed, 12 total")
+
+	// The existing test suites are kept.
+	result, err = ScaffoldV3(chartPath, ScaffoldOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, result.Written)
+	assert.Len(t, result.Skipped, 12)
+}
@@ -0,0 +1,89 @@
+package unittest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
+	v3util "helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/strvals"
+)
+
+// SetValues the values of the suite and the test job, which are parsed the same way as the options with the same
+// name of helm install. The key is the path of the value and the value is the text after the `=` of the option.
+type SetValues struct {
+	// SetString the values set as string, like --set-string
+	SetString map[string]string `yaml:"setString"`
+	// SetFile the values set to the content of a file relative to the test suite file, like --set-file
+	SetFile map[string]string `yaml:"setFile"`
+	// SetJSON the values set to a JSON value, like --set-json
+	SetJSON map[string]string `yaml:"setJSON"`
+	// SetLiteral the values set to the literal text, like --set-literal
+	SetLiteral map[string]string `yaml:"setLiteral"`
+}
+
+// mergeSetValues merges the set values into the base values, with the precedence of helm install:
+// set-json, set, set-string, set-file and set-literal, where the later override the earlier values.
+func (t *TestJob) mergeSetValues(base map[string]interface{}, routes []string, set map[string]interface{}, setValues SetValues) (map[string]interface{}, error) {
+	merge := func(values map[string]interface{}) {
+		base = v3util.MergeTables(scopeValuesWithRoutes(routes, values), base)
+	}
+	parseInto := func(option string, values map[string]string, parse func(string, map[string]interface{}) error) error {
+		for _, path := range sortedKeys(values) {
+			value, err := t.substituteVars(values[path])
+			if err != nil {
+				return fmt.Errorf("failed to substitute %s %s: %w", option, path, err)
+			}
+			setMap := map[string]interface{}{}
+			if err := parse(path+"="+value, setMap); err != nil {
+				return fmt.Errorf("failed to parse %s %s: %w", option, path, err)
+			}
+			merge(setMap)
+		}
+		return nil
+	}
+
+	if err := parseInto("setJSON", setValues.SetJSON, strvals.ParseJSON); err != nil {
+		return nil, err
+	}
+
+	for path, values := range set {
+		values, err := t.substituteVarsInValue(values)
+		if err != nil {
+			return nil, fmt.Errorf("failed to substitute set %s: %w", path, err)
+		}
+		setMap, err := valueutils.BuildValueOfSetPath(values, path)
+		if err != nil {
+			return nil, err
+		}
+		merge(setMap)
+	}
+
+	if err := parseInto("setString", setValues.SetString, strvals.ParseIntoString); err != nil {
+		return nil, err
+	}
+	readFile := func(line string, dest map[string]interface{}) error {
+		return strvals.ParseIntoFile(line, dest, t.readSetFile)
+	}
+	if err := parseInto("setFile", setValues.SetFile, readFile); err != nil {
+		return nil, err
+	}
+	if err := parseInto("setLiteral", setValues.SetLiteral, strvals.ParseLiteralInto); err != nil {
+		return nil, err
+	}
+	return base, nil
+}
+
+// readSetFile returns the content of the file of setFile, relative paths are resolved from the test suite file.
+func (t *TestJob) readSetFile(file []rune) (interface{}, error) {
+	filePath := string(file)
+	if !filepath.IsAbs(filePath) {
+		filePath = filepath.Join(filepath.Dir(t.definitionFile), filePath)
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return string(content), nil
+}
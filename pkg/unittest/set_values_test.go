@@ -0,0 +1,100 @@
+package unittest_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest"
+)
+
+func TestV3RunnerWithSetValues(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "basic")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS(testV3BasicChart)))
+	assert.NoError(t, os.RemoveAll(filepath.Join(chartPath, "tests")))
+	assert.NoError(t, os.MkdirAll(filepath.Join(chartPath, "tests", "files"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "files", "pull-policy.txt"), []byte("Always"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "deployment_test.yaml"), []byte(`suite: set values
+templates:
+  - configmap.yaml
+  - deployment.yaml
+setString:
+  image.tag: 1.10
+setFile:
+  image.pullPolicy: files/pull-policy.txt
+tests:
+  - it: should parse the values like helm install
+    template: deployment.yaml
+    documentIndex: 0
+    setJSON:
+      resources: '{"limits":{"cpu":"1"}}'
+      nameOverride: '"json"'
+    set:
+      nameOverride: set
+    setLiteral:
+      nameOverride: a,b\c
+    asserts:
+      - equal:
+          path: spec.template.spec.containers[0].image
+          value: nginx:1.10
+      - equal:
+          path: spec.template.spec.containers[0].imagePullPolicy
+          value: Always
+      - equal:
+          path: spec.template.spec.containers[0].resources
+          value:
+            limits:
+              cpu: "1"
+      - equal:
+          path: metadata.name
+          value: RELEASE-NAME-a,b\c
+  - it: should override the suite values
+    template: deployment.yaml
+    documentIndex: 0
+    set:
+      image.tag: latest
+    asserts:
+      - equal:
+          path: spec.template.spec.containers[0].image
+          value: nginx:latest
+`), 0644))
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/*_test.yaml"},
+		Strict:    true,
+	}
+	assert.True(t, runner.RunV3([]string{chartPath}), buffer.String())
+	assert.Contains(t, buffer.String(), "Tests:       2 passed, 2 total")
+}
+
+func TestV3RunnerWithInvalidSetJSON(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "basic")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS(testV3BasicChart)))
+	assert.NoError(t, os.RemoveAll(filepath.Join(chartPath, "tests")))
+	assert.NoError(t, os.Mkdir(filepath.Join(chartPath, "tests"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "deployment_test.yaml"), []byte(`suite: invalid set values
+templates:
+  - deployment.yaml
+tests:
+  - it: should fail to parse the JSON
+    setJSON:
+      resources: '{"limits":'
+    asserts:
+      - hasDocuments:
+          count: 1
+`), 0644))
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/*_test.yaml"},
+	}
+	assert.False(t, runner.RunV3([]string{chartPath}))
+	assert.Contains(t, buffer.String(), "failed to parse setJSON resources")
+}
@@ -1,39 +1,123 @@
 package snapshot
 
 import (
-	"bytes"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/helm-unittest/helm-unittest/internal/common"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
+	"gopkg.in/yaml.v3"
 )
 
 // CompareResult result return by Cache.Compare
 type CompareResult struct {
-	Passed         bool
-	Test           string
-	Index          uint
+	Passed bool
+	Test   string
+	Index  uint
+	Key    string
+	// Diff the structural diff of the snapshots, empty when the snapshots are compared by line
+	Diff           string
 	NewSnapshot    string
 	CachedSnapshot string
 	Msg            string
 	Err            error
 }
 
+// PendingSnapshot a changed or new snapshot collected for review
+type PendingSnapshot struct {
+	Test string
+	// Key the key to store the snapshot, the name or the index of the snapshot
+	Key            string
+	CachedSnapshot string
+	NewSnapshot    string
+	// IsNew whether no snapshot was cached before
+	IsNew bool
+	// legacyKey the key of the cached snapshot stored by index, migrated when accepted
+	legacyKey string
+	document  string
+}
+
+// snapshotsOfTest the snapshots of a test by key
+type snapshotsOfTest map[string]string
+
+// MarshalYAML stores the snapshots by index first, in the order of the index, followed by the named snapshots
+func (s snapshotsOfTest) MarshalYAML() (interface{}, error) {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		idxI, errI := strconv.ParseUint(keys[i], 10, 64)
+		idxJ, errJ := strconv.ParseUint(keys[j], 10, 64)
+		if errI == nil && errJ == nil {
+			return idxI < idxJ
+		}
+		if (errI == nil) != (errJ == nil) {
+			return errI == nil
+		}
+		return keys[i] < keys[j]
+	})
+
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, key := range keys {
+		keyNode := &yaml.Node{}
+		if err := keyNode.Encode(key); err != nil {
+			return nil, err
+		}
+		if _, err := strconv.ParseUint(key, 10, 64); err == nil {
+			keyNode = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: key}
+		}
+		valueNode := &yaml.Node{}
+		if err := valueNode.Encode(s[key]); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, keyNode, valueNode)
+	}
+	return node, nil
+}
+
 // Cache manage snapshot caching
 type Cache struct {
-	Filepath      string
-	Existed       bool
-	IsUpdating    bool
-	cached        map[string]map[uint]string
-	current       map[string]map[uint]string
+	// Filepath the snapshot file, or the snapshot directory of the suite in the document layout
+	Filepath string
+	Layout   Layout
+	// Suite the file name of the test suite owning the snapshots, which is recorded in the snapshot files
+	Suite      string
+	Existed    bool
+	IsUpdating bool
+	// IsPruning removes the cached snapshots which are not compared, instead of keeping them
+	IsPruning bool
+	// IsCI fails the comparison of missing snapshots, instead of storing the new snapshot
+	IsCI bool
+	// IsStructuralDiff reports the changed snapshots as changed paths, instead of changed lines
+	IsStructuralDiff bool
+	// IsReviewing collects the changed and new snapshots for review, instead of storing new snapshots
+	IsReviewing   bool
+	recordedSuite string
+	cached        map[string]snapshotsOfTest
+	current       map[string]snapshotsOfTest
+	documents     map[string]map[string]string
+	convertFrom   string
+	migrated      map[string]map[string]bool
+	retained      map[string]bool
 	updatedCount  uint
 	insertedCount uint
+	missingCount  uint
 	currentCount  uint
+	migratedCount uint
+	acceptedCount uint
+	pending       []PendingSnapshot
 }
 
 // RestoreFromFile restore cached snapshot from cache file
 func (s *Cache) RestoreFromFile() error {
+	if s.Layout == DocumentLayout {
+		return s.restoreFromDocuments()
+	}
+
 	content, err := os.ReadFile(s.Filepath)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -45,20 +129,43 @@ func (s *Cache) RestoreFromFile() error {
 	if err := common.YmlUnmarshal(string(content), &s.cached); err != nil {
 		return err
 	}
+	s.recordedSuite, _ = RecordedSuite(s.Filepath)
 	s.Existed = true
 	return nil
 }
 
-func (s *Cache) getCached(test string, idx uint) (string, bool) {
+// restoreFromDocuments restore cached snapshot from the snapshot files of the documents
+func (s *Cache) restoreFromDocuments() error {
+	if _, err := os.Stat(s.Filepath); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	cached, documents, err := restoreDocuments(s.Filepath)
+	if err != nil {
+		return err
+	}
+	s.cached = cached
+	s.documents = documents
+	s.recordedSuite, _ = RecordedSuite(s.Filepath)
+	s.Existed = true
+	return nil
+}
+
+func (s *Cache) getCached(test string, key string) (string, bool) {
 	if cachedByTest, ok := s.cached[test]; ok {
-		if cachedOfAssertion, ok := cachedByTest[idx]; ok {
+		if cachedOfAssertion, ok := cachedByTest[key]; ok {
 			return cachedOfAssertion, true
 		}
 	}
 	return "", false
 }
 
-// Compare content to cached last time, return CompareResult
+// Compare content to cached last time, return CompareResult.
+// The snapshot is stored by the name in the options, or by the index when no name is given.
+// Named snapshots fall back to the snapshot stored by the index, which is migrated to the name when updating.
 func (s *Cache) Compare(test string, idx uint, content interface{}, optFns ...func(options *CacheOptions) error) *CompareResult {
 	var options CacheOptions
 	var err error
@@ -71,16 +178,53 @@ func (s *Cache) Compare(test string, idx uint, content interface{}, optFns ...fu
 	}
 
 	s.currentCount++
-	cached, existed := s.getCached(test, idx)
+	key := strconv.FormatUint(uint64(idx), 10)
+	legacyKey := ""
+	if options.Name != "" {
+		legacyKey, key = key, options.Name
+	}
+
+	cached, existed := s.getCached(test, key)
+	migrated := false
+	if !existed && legacyKey != "" {
+		cached, existed = s.getCached(test, legacyKey)
+		migrated = existed
+	}
+	reviewing := s.IsReviewing && !s.IsUpdating && options.MatchRegexPattern == "" && options.NotMatchRegexPattern == ""
+	if !existed && reviewing {
+		newSnapshot := common.TrustedMarshalYAML(content)
+		s.missingCount++
+		s.pending = append(s.pending, PendingSnapshot{Test: test, Key: key, NewSnapshot: newSnapshot, IsNew: true, document: options.Document})
+		// The snapshot passes until it is rejected by the review.
+		return &CompareResult{
+			Passed:      true,
+			Test:        test,
+			Index:       idx,
+			Key:         options.Name,
+			NewSnapshot: newSnapshot,
+			Msg:         fmt.Sprintf(" snapshot %s to be reviewed, new snapshots are stored when accepted", key),
+		}
+	}
+	if !existed && s.IsCI && !s.IsUpdating {
+		s.missingCount++
+		return &CompareResult{
+			Passed: false,
+			Test:   test,
+			Index:  idx,
+			Key:    options.Name,
+			Msg:    fmt.Sprintf(" snapshot %s to be stored, new snapshots are not stored in CI mode", key),
+		}
+	}
 	if !existed {
 		s.insertedCount++
 	}
 
 	match := true
+	pendingReview := false
 
 	newSnapshot := common.TrustedMarshalYAML(content)
 
-	if len(optFns) > 0 {
+	if options.MatchRegexPattern != "" || options.NotMatchRegexPattern != "" {
 		if options.MatchRegexPattern != "" {
 			match, err = valueutils.MatchesPattern(newSnapshot, options.MatchRegexPattern)
 			if !match {
@@ -100,6 +244,21 @@ func (s *Cache) Compare(test string, idx uint, content interface{}, optFns ...fu
 		if existed && newSnapshot != cached {
 			match = false
 			s.updatedCount++
+			if reviewing {
+				pending := PendingSnapshot{Test: test, Key: key, CachedSnapshot: cached, NewSnapshot: newSnapshot, document: options.Document}
+				if migrated {
+					pending.legacyKey = legacyKey
+				}
+				s.pending = append(s.pending, pending)
+				pendingReview = true
+			}
+		}
+	}
+
+	var structuralDiff string
+	if !match && s.IsStructuralDiff {
+		if changes, diffErr := StructuralDiff(cached, newSnapshot); diffErr == nil {
+			structuralDiff = strings.Join(changes, "\n")
 		}
 	}
 
@@ -109,14 +268,27 @@ func (s *Cache) Compare(test string, idx uint, content interface{}, optFns ...fu
 	} else {
 		snapshotToSave = cached
 	}
-	s.setNewSnapshot(test, idx, snapshotToSave)
+	if migrated && !s.IsUpdating {
+		// Keep the snapshot by the index, until the snapshots are updated.
+		s.setNewSnapshot(test, legacyKey, snapshotToSave)
+		s.setDocument(test, legacyKey, options.Document)
+	} else {
+		if migrated {
+			s.markMigrated(test, legacyKey)
+		}
+		s.setNewSnapshot(test, key, snapshotToSave)
+		s.setDocument(test, key, options.Document)
+	}
 
-	match = s.IsUpdating || match
+	// The changed snapshot passes until it is rejected by the review.
+	match = s.IsUpdating || pendingReview || match
 
 	return &CompareResult{
 		Passed:         match,
 		Test:           test,
 		Index:          idx,
+		Key:            options.Name,
+		Diff:           structuralDiff,
 		CachedSnapshot: cached,
 		NewSnapshot:    newSnapshot,
 		Msg:            msg,
@@ -124,20 +296,62 @@ func (s *Cache) Compare(test string, idx uint, content interface{}, optFns ...fu
 	}
 }
 
-func (s *Cache) setNewSnapshot(test string, idx uint, snapshot string) {
+// markMigrated marks the snapshot stored by index as migrated to the name.
+func (s *Cache) markMigrated(test, legacyKey string) {
+	s.migratedCount++
+	if s.migrated == nil {
+		s.migrated = make(map[string]map[string]bool)
+	}
+	if s.migrated[test] == nil {
+		s.migrated[test] = make(map[string]bool)
+	}
+	s.migrated[test][legacyKey] = true
+}
+
+func (s *Cache) setNewSnapshot(test string, key string, snapshot string) {
 	if s.current == nil {
-		s.current = make(map[string]map[uint]string)
+		s.current = make(map[string]snapshotsOfTest)
 	}
 	if newCacheOfTest, ok := s.current[test]; ok {
-		newCacheOfTest[idx] = snapshot
+		newCacheOfTest[key] = snapshot
 	} else {
-		s.current[test] = map[uint]string{idx: snapshot}
+		s.current[test] = snapshotsOfTest{key: snapshot}
+	}
+}
+
+// setDocument records the document of the snapshot, used to name the snapshot file in the document layout.
+func (s *Cache) setDocument(test, key, document string) {
+	if document == "" {
+		return
 	}
+	if s.documents == nil {
+		s.documents = make(map[string]map[string]string)
+	}
+	if s.documents[test] == nil {
+		s.documents[test] = make(map[string]string)
+	}
+	s.documents[test][key] = document
+}
+
+// Pending returns the changed and new snapshots collected for review, in the order of comparison.
+func (s *Cache) Pending() []PendingSnapshot {
+	return s.pending
+}
+
+// Accept stores the new snapshot of the pending snapshot, snapshots cached by index are migrated to the name.
+func (s *Cache) Accept(pending PendingSnapshot) {
+	if pending.legacyKey != "" {
+		delete(s.current[pending.Test], pending.legacyKey)
+		s.markMigrated(pending.Test, pending.legacyKey)
+	}
+	s.setNewSnapshot(pending.Test, pending.Key, pending.NewSnapshot)
+	s.setDocument(pending.Test, pending.Key, pending.document)
+	s.acceptedCount++
 }
 
 // Changed check if content have changed according to all Compare called
 func (s *Cache) Changed() bool {
-	if s.updatedCount > 0 || s.insertedCount > 0 {
+	if s.updatedCount > 0 || s.insertedCount > 0 || s.acceptedCount > 0 || s.convertFrom != "" {
 		return true
 	}
 
@@ -145,8 +359,8 @@ func (s *Cache) Changed() bool {
 		if _, ok := s.current[test]; !ok {
 			return true
 		}
-		for idx := range cachedFiles {
-			if _, ok := s.current[test][idx]; !ok {
+		for key := range cachedFiles {
+			if _, ok := s.current[test][key]; !ok {
 				return true
 			}
 		}
@@ -154,29 +368,104 @@ func (s *Cache) Changed() bool {
 	return false
 }
 
-// StoreToFileIfNeeded store current cache to file if snapshot content changed
+// Retain keeps the cached snapshots of the test when pruning, used for tests which are not run.
+func (s *Cache) Retain(test string) {
+	if s.retained == nil {
+		s.retained = make(map[string]bool)
+	}
+	s.retained[test] = true
+}
+
+// isVanished returns whether the cached snapshot is not compared this time, migrated snapshots are not vanished.
+func (s *Cache) isVanished(test, key string) bool {
+	if _, ok := s.current[test][key]; ok {
+		return false
+	}
+	return !s.migrated[test][key]
+}
+
+// snapshotsToStore returns the current snapshots, including the vanished snapshots unless pruning.
+func (s *Cache) snapshotsToStore() map[string]snapshotsOfTest {
+	snapshots := make(map[string]snapshotsOfTest, len(s.current))
+	for test, current := range s.current {
+		snapshots[test] = make(snapshotsOfTest, len(current))
+		for key, snapshot := range current {
+			snapshots[test][key] = snapshot
+		}
+	}
+	for test, cachedFiles := range s.cached {
+		for key, snapshot := range cachedFiles {
+			if !s.isVanished(test, key) || (s.IsPruning && !s.retained[test]) {
+				continue
+			}
+			if snapshots[test] == nil {
+				snapshots[test] = make(snapshotsOfTest)
+			}
+			snapshots[test][key] = snapshot
+		}
+	}
+	return snapshots
+}
+
+// StoreToFileIfNeeded store current cache to file if snapshot content changed.
+// Vanished snapshots are only removed when pruning, the file is removed when no snapshots remain.
 func (s *Cache) StoreToFileIfNeeded() (bool, error) {
-	if !s.Changed() {
+	// Snapshot files stored without the owning suite are rewritten when pruning, so they can be pruned later on.
+	recording := s.IsPruning && s.Existed && s.Suite != "" && s.recordedSuite != s.Suite
+	if !s.Changed() && !recording {
 		return false, nil
 	}
 
-	if s.IsUpdating || s.insertedCount > 0 || s.VanishedCount() > 0 {
-		byteBuffer := new(bytes.Buffer)
-		yamlEncoder := common.YamlNewEncoder(byteBuffer)
-		yamlEncoder.SetIndent(common.YAMLINDENTION)
-		if err := yamlEncoder.Encode(s.current); err != nil {
+	if s.IsUpdating || s.insertedCount > 0 || s.migratedCount > 0 || s.acceptedCount > 0 || s.convertFrom != "" || (s.IsPruning && s.VanishedCount() > 0) || recording {
+		if err := s.store(s.snapshotsToStore()); err != nil {
 			return false, err
 		}
+		if s.convertFrom != "" {
+			if err := os.RemoveAll(s.convertFrom); err != nil {
+				return false, err
+			}
+			s.convertFrom = ""
+		}
+		return true, nil
+	}
 
-		if err := os.WriteFile(s.Filepath, byteBuffer.Bytes(), 0644); err != nil {
-			return false, err
+	return false, nil
+}
+
+// store writes the snapshots in the layout of the cache, the snapshot file or directory is removed
+// when no snapshots remain.
+func (s *Cache) store(snapshots map[string]snapshotsOfTest) error {
+	if len(snapshots) == 0 {
+		if err := os.RemoveAll(s.Filepath); err != nil {
+			return err
 		}
+		s.Existed = false
+		return nil
+	}
 
+	if s.Layout == DocumentLayout {
+		files, err := documentFiles(snapshots, s.documents, s.Suite)
+		if err != nil {
+			return err
+		}
+		if err := storeDocuments(s.Filepath, files); err != nil {
+			return err
+		}
 		s.Existed = true
-		return true, nil
+		s.recordedSuite = s.Suite
+		return nil
 	}
 
-	return false, nil
+	content, err := encodeYAML(snapshots)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.Filepath, withSuiteHeader(s.Suite, content), 0644); err != nil {
+		return err
+	}
+	s.Existed = true
+	s.recordedSuite = s.Suite
+	return nil
 }
 
 // UpdatedCount return snapshot count that was cached before and updated current time
@@ -189,30 +478,59 @@ func (s *Cache) InsertedCount() uint {
 	return s.insertedCount
 }
 
+// MigratedCount return snapshot count that was cached by index and stored by name current time
+func (s *Cache) MigratedCount() uint {
+	return s.migratedCount
+}
+
 // CurrentCount return total snapshot count of current time
 func (s *Cache) CurrentCount() uint {
 	return s.currentCount
 }
 
+// PrunedCount return vanished snapshot count that is removed when pruning
+func (s *Cache) PrunedCount() uint {
+	if !s.IsPruning {
+		return 0
+	}
+	var count uint
+	for test, cachedFiles := range s.cached {
+		for key := range cachedFiles {
+			if s.isVanished(test, key) && !s.retained[test] {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// AcceptedCount return reviewed snapshot count that was accepted current time
+func (s *Cache) AcceptedCount() uint {
+	return s.acceptedCount
+}
+
+// MissingCount return snapshot count that was not cached and not inserted in CI mode
+func (s *Cache) MissingCount() uint {
+	return s.missingCount
+}
+
 // FailedCount return snapshot count that was failed when Compare
 func (s *Cache) FailedCount() uint {
 	if s.IsUpdating {
 		return 0
 	}
-	return s.updatedCount
+	// The snapshots to be reviewed fail when rejected by the review.
+	return s.updatedCount + s.missingCount - uint(len(s.pending))
 }
 
 // VanishedCount return snapshot count that was cached last time but not exists this time
 func (s *Cache) VanishedCount() uint {
 	var count uint
 	for test, cachedFiles := range s.cached {
-		for idx := range cachedFiles {
-			if newTestCache, ok := s.current[test]; ok {
-				if _, ok := newTestCache[idx]; ok {
-					continue
-				}
+		for key := range cachedFiles {
+			if s.isVanished(test, key) {
+				count++
 			}
-			count++
 		}
 	}
 	return count
@@ -222,8 +540,27 @@ func (s *Cache) VanishedCount() uint {
 type CacheOptionsFunc func(*CacheOptions) error
 
 type CacheOptions struct {
+	Name                 string
 	MatchRegexPattern    string
 	NotMatchRegexPattern string
+	// Document the kind and name of the document, used to name the snapshot file in the document layout
+	Document string
+}
+
+// WithName stores the snapshot by name instead of the index
+func WithName(name string) CacheOptionsFunc {
+	return func(c *CacheOptions) error {
+		c.Name = name
+		return nil
+	}
+}
+
+// WithDocument stores the snapshot with the snapshots of the document in the document layout
+func WithDocument(document string) CacheOptionsFunc {
+	return func(c *CacheOptions) error {
+		c.Document = document
+		return nil
+	}
 }
 
 func WithMatchRegexPattern(pattern string) CacheOptionsFunc {
@@ -212,9 +212,36 @@ func TestCacheWhenChangedIfIsUpdating(t *testing.T) {
 `, string(bytes))
 }
 
+func TestCacheWhenHasVanishedIfNotPruning(t *testing.T) {
+	a := assert.New(t)
+	cache := createCache(a, true)
+	err := cache.RestoreFromFile()
+
+	a.Nil(err)
+	cache.Compare(cache_before, 1, content1)
+	verifyCache(a, cache, true, true, 1, 0, 0, 0, 1)
+
+	stored, storeErr := cache.StoreToFileIfNeeded()
+	a.False(stored)
+	a.Nil(storeErr)
+
+	cache.Compare("new test", 1, contentNew)
+	stored, storeErr = cache.StoreToFileIfNeeded()
+	a.True(stored)
+	a.Nil(storeErr)
+
+	bytes, _ := os.ReadFile(cache.Filepath)
+	a.Equal(lastTimeContent+`new test:
+  1: |
+    x:
+      "y": z
+`, string(bytes))
+}
+
 func TestCacheWhenHasVanished(t *testing.T) {
 	a := assert.New(t)
 	cache := createCache(a, true)
+	cache.IsPruning = true
 	err := cache.RestoreFromFile()
 
 	a.Nil(err)
@@ -350,6 +377,19 @@ func TestCacheWhenNewOneAtMiddleIfIsUpdating(t *testing.T) {
 `, string(bytes))
 }
 
+func TestCacheWhenChangedWithEmptyRegexPatterns(t *testing.T) {
+	a := assert.New(t)
+	cache := createCache(a, true)
+	err := cache.RestoreFromFile()
+
+	a.Nil(err)
+
+	// matchSnapshot passes empty patterns without matchRegex or notMatchRegex, the content is compared.
+	result := cache.Compare(cache_before, 2, contentNew, WithMatchRegexPattern(""), WithNotMatchRegexPattern(""))
+	a.Equal(createCacheResult(2, false, snapshot2, snapshotNew), result)
+	verifyCache(a, cache, true, true, 1, 0, 1, 1, 1)
+}
+
 func TestCacheWhenRegexMatch(t *testing.T) {
 	a := assert.New(t)
 	cache := createCache(a, true)
@@ -380,3 +420,221 @@ func TestCacheWhenNotRegexMatch(t *testing.T) {
 	a.False(result.Passed)
 	a.Contains(result.Msg, "pattern 'b: c' should not be in snapshot")
 }
+
+func TestCacheWhenNamedAndChanged(t *testing.T) {
+	a := assert.New(t)
+	cache := createCache(a, false)
+
+	cache.Compare(cache_before, 1, content1, WithName("deployment.yaml Deployment/app"))
+	_, storeErr := cache.StoreToFileIfNeeded()
+	a.Nil(storeErr)
+
+	cache = &Cache{Filepath: cache.Filepath}
+	a.Nil(cache.RestoreFromFile())
+
+	result := cache.Compare(cache_before, 2, contentNew, WithName("deployment.yaml Deployment/app"))
+	a.False(result.Passed)
+	a.Equal("deployment.yaml Deployment/app", result.Key)
+	a.Equal(snapshot1, result.CachedSnapshot)
+	verifyCache(a, cache, true, true, 1, 0, 1, 1, 0)
+}
+
+func TestCacheWhenNamedAndCachedByIndex(t *testing.T) {
+	a := assert.New(t)
+	cache := createCache(a, true)
+	a.Nil(cache.RestoreFromFile())
+
+	result := cache.Compare(cache_before, 1, content1, WithName("first"))
+	a.True(result.Passed)
+	a.Equal(snapshot1, result.CachedSnapshot)
+	result2 := cache.Compare(cache_before, 2, contentNew, WithName("second"))
+	a.False(result2.Passed)
+	verifyCache(a, cache, true, true, 2, 0, 1, 1, 0)
+	a.Equal(uint(0), cache.MigratedCount())
+
+	stored, storeErr := cache.StoreToFileIfNeeded()
+	a.False(stored)
+	a.Nil(storeErr)
+
+	bytes, _ := os.ReadFile(cache.Filepath)
+	a.Equal(lastTimeContent, string(bytes))
+}
+
+func TestCacheWhenNamedAndCachedByIndexIfIsUpdating(t *testing.T) {
+	a := assert.New(t)
+	cache := createCache(a, true)
+	cache.IsUpdating = true
+	a.Nil(cache.RestoreFromFile())
+
+	cache.Compare(cache_before, 1, content1, WithName("first"))
+	cache.Compare(cache_before, 2, content2, WithName("second"))
+	cache.Compare(cache_before, 3, contentNew)
+	verifyCache(a, cache, true, true, 3, 1, 0, 0, 0)
+	a.Equal(uint(2), cache.MigratedCount())
+
+	stored, storeErr := cache.StoreToFileIfNeeded()
+	a.True(stored)
+	a.Nil(storeErr)
+
+	expectedCacheContent := `cached before:
+  3: |
+    x:
+      "y": z
+  first: |
+    a:
+      b: c
+  second: |
+    d:
+      e: f
+`
+	bytes, _ := os.ReadFile(cache.Filepath)
+	a.Equal(expectedCacheContent, string(bytes))
+}
+
+func TestCacheWhenAllVanishedIfPruning(t *testing.T) {
+	a := assert.New(t)
+	cache := createCache(a, true)
+	cache.IsPruning = true
+	a.Nil(cache.RestoreFromFile())
+
+	stored, storeErr := cache.StoreToFileIfNeeded()
+	a.True(stored)
+	a.Nil(storeErr)
+	a.False(cache.Existed)
+	a.NoFileExists(cache.Filepath)
+}
+
+func TestCacheWhenRetainedIfPruning(t *testing.T) {
+	a := assert.New(t)
+	cache := createCache(a, true)
+	cache.IsPruning = true
+	a.Nil(cache.RestoreFromFile())
+
+	cache.Compare("new test", 1, contentNew)
+	cache.Retain(cache_before)
+	stored, storeErr := cache.StoreToFileIfNeeded()
+	a.True(stored)
+	a.Nil(storeErr)
+
+	bytes, _ := os.ReadFile(cache.Filepath)
+	a.Equal(lastTimeContent+`new test:
+  1: |
+    x:
+      "y": z
+`, string(bytes))
+}
+
+func TestCacheWhenMissingIfCI(t *testing.T) {
+	a := assert.New(t)
+	cache := createCache(a, true)
+	cache.IsCI = true
+	a.Nil(cache.RestoreFromFile())
+
+	result := cache.Compare(cache_before, 1, content1)
+	a.True(result.Passed)
+	result = cache.Compare(cache_before, 3, contentNew)
+	a.False(result.Passed)
+	a.Equal(" snapshot 3 to be stored, new snapshots are not stored in CI mode", result.Msg)
+	verifyCache(a, cache, true, true, 2, 0, 0, 1, 1)
+	a.Equal(uint(1), cache.MissingCount())
+
+	stored, storeErr := cache.StoreToFileIfNeeded()
+	a.False(stored)
+	a.Nil(storeErr)
+}
+
+func TestCacheWhenReviewing(t *testing.T) {
+	a := assert.New(t)
+	cache := createCache(a, true)
+	cache.IsReviewing = true
+	a.Nil(cache.RestoreFromFile())
+
+	// The snapshots to be reviewed pass, until rejected by the review.
+	result := cache.Compare(cache_before, 1, content1)
+	a.True(result.Passed)
+	result = cache.Compare(cache_before, 2, contentNew)
+	a.True(result.Passed)
+	result = cache.Compare(cache_before, 3, content2)
+	a.True(result.Passed)
+	a.Equal(" snapshot 3 to be reviewed, new snapshots are stored when accepted", result.Msg)
+	result = cache.Compare("new test", 1, contentNew)
+	a.True(result.Passed)
+	a.Equal(uint(0), cache.FailedCount())
+
+	pending := cache.Pending()
+	a.Equal([]PendingSnapshot{
+		{Test: cache_before, Key: "2", CachedSnapshot: snapshot2, NewSnapshot: snapshotNew},
+		{Test: cache_before, Key: "3", NewSnapshot: snapshot2, IsNew: true},
+		{Test: "new test", Key: "1", NewSnapshot: snapshotNew, IsNew: true},
+	}, pending)
+
+	cache.Accept(pending[0])
+	cache.Accept(pending[2])
+	a.Equal(uint(2), cache.AcceptedCount())
+
+	stored, storeErr := cache.StoreToFileIfNeeded()
+	a.True(stored)
+	a.Nil(storeErr)
+
+	bytes, _ := os.ReadFile(cache.Filepath)
+	a.Equal(`cached before:
+  1: |
+    a:
+      b: c
+  2: |
+    x:
+      "y": z
+new test:
+  1: |
+    x:
+      "y": z
+`, string(bytes))
+}
+
+func TestCacheWhenReviewingNamedAndCachedByIndex(t *testing.T) {
+	a := assert.New(t)
+	cache := createCache(a, true)
+	cache.IsReviewing = true
+	a.Nil(cache.RestoreFromFile())
+
+	cache.Compare(cache_before, 1, content1)
+	cache.Compare(cache_before, 2, contentNew, WithName("second"))
+	pending := cache.Pending()
+	a.Len(pending, 1)
+	a.Equal("second", pending[0].Key)
+
+	cache.Accept(pending[0])
+	stored, storeErr := cache.StoreToFileIfNeeded()
+	a.True(stored)
+	a.Nil(storeErr)
+	a.Equal(uint(1), cache.MigratedCount())
+
+	bytes, _ := os.ReadFile(cache.Filepath)
+	a.Equal(`cached before:
+  1: |
+    a:
+      b: c
+  second: |
+    x:
+      "y": z
+`, string(bytes))
+}
+
+func TestCacheWhenReviewingNothingAccepted(t *testing.T) {
+	a := assert.New(t)
+	cache := createCache(a, true)
+	cache.IsReviewing = true
+	a.Nil(cache.RestoreFromFile())
+
+	cache.Compare(cache_before, 1, content1)
+	cache.Compare(cache_before, 2, contentNew)
+	cache.Compare("new test", 1, contentNew)
+	a.Len(cache.Pending(), 2)
+
+	stored, storeErr := cache.StoreToFileIfNeeded()
+	a.False(stored)
+	a.Nil(storeErr)
+
+	bytes, _ := os.ReadFile(cache.Filepath)
+	a.Equal(lastTimeContent, string(bytes))
+}
@@ -0,0 +1,179 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+)
+
+// noValue the value shown for paths which are added or removed
+const noValue = "<none>"
+
+// StructuralDiff returns the changes between the snapshots as paths, like
+// `spec.template.spec.containers[0].image: "a:1" -> "a:2"`. Items of lists are matched by name when possible.
+// An error is returned when the snapshots are no yaml, or the changes can not be expressed as paths.
+func StructuralDiff(expected, actual string) ([]string, error) {
+	var expectedValue, actualValue interface{}
+	if err := common.YmlUnmarshal(expected, &expectedValue); err != nil {
+		return nil, err
+	}
+	if err := common.YmlUnmarshal(actual, &actualValue); err != nil {
+		return nil, err
+	}
+
+	changes := diffValues("", expectedValue, actualValue, nil)
+	if len(changes) == 0 && expected != actual {
+		return nil, fmt.Errorf("no structural changes found")
+	}
+	return changes, nil
+}
+
+func diffValues(path string, expected, actual interface{}, changes []string) []string {
+	expectedMap, expectedIsMap := expected.(map[string]interface{})
+	actualMap, actualIsMap := actual.(map[string]interface{})
+	if expectedIsMap && actualIsMap {
+		return diffMaps(path, expectedMap, actualMap, changes)
+	}
+
+	expectedList, expectedIsList := expected.([]interface{})
+	actualList, actualIsList := actual.([]interface{})
+	if expectedIsList && actualIsList {
+		if names, ok := namesOfLists(expectedList, actualList); ok {
+			return diffNamedLists(path, expectedList, actualList, names, changes)
+		}
+		return diffLists(path, expectedList, actualList, changes)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		changes = append(changes, fmt.Sprintf("%s: %s -> %s", displayPath(path), formatValue(expected), formatValue(actual)))
+	}
+	return changes
+}
+
+func diffMaps(path string, expected, actual map[string]interface{}, changes []string) []string {
+	keys := make([]string, 0, len(expected)+len(actual))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	for key := range actual {
+		if _, ok := expected[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		expectedValue, inExpected := expected[key]
+		actualValue, inActual := actual[key]
+		keyPath := joinKey(path, key)
+		switch {
+		case !inExpected:
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", keyPath, noValue, formatValue(actualValue)))
+		case !inActual:
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", keyPath, formatValue(expectedValue), noValue))
+		default:
+			changes = diffValues(keyPath, expectedValue, actualValue, changes)
+		}
+	}
+	return changes
+}
+
+func diffLists(path string, expected, actual []interface{}, changes []string) []string {
+	for idx := 0; idx < max(len(expected), len(actual)); idx++ {
+		idxPath := fmt.Sprintf("%s[%d]", path, idx)
+		switch {
+		case idx >= len(expected):
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", idxPath, noValue, formatValue(actual[idx])))
+		case idx >= len(actual):
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", idxPath, formatValue(expected[idx]), noValue))
+		default:
+			changes = diffValues(idxPath, expected[idx], actual[idx], changes)
+		}
+	}
+	return changes
+}
+
+// diffNamedLists matches the items by name, matched and added items are reported by the index of the actual item,
+// removed items by the index of the expected item.
+func diffNamedLists(path string, expected, actual []interface{}, names [2][]string, changes []string) []string {
+	expectedByName := make(map[string]int, len(expected))
+	for idx, name := range names[0] {
+		expectedByName[name] = idx
+	}
+	actualByName := make(map[string]bool, len(actual))
+
+	for idx, name := range names[1] {
+		actualByName[name] = true
+		idxPath := fmt.Sprintf("%s[%d]", path, idx)
+		expectedIdx, ok := expectedByName[name]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", idxPath, noValue, formatValue(actual[idx])))
+			continue
+		}
+		if expectedIdx != idx {
+			changes = append(changes, fmt.Sprintf("%s: moved from [%d]", idxPath, expectedIdx))
+		}
+		changes = diffValues(idxPath, expected[expectedIdx], actual[idx], changes)
+	}
+
+	for idx, name := range names[0] {
+		if !actualByName[name] {
+			changes = append(changes, fmt.Sprintf("%s[%d]: %s -> %s", path, idx, formatValue(expected[idx]), noValue))
+		}
+	}
+	return changes
+}
+
+// namesOfLists returns the names of the items of both lists, when all items are maps with a unique name.
+func namesOfLists(expected, actual []interface{}) ([2][]string, bool) {
+	var names [2][]string
+	for listIdx, list := range [][]interface{}{expected, actual} {
+		unique := make(map[string]bool, len(list))
+		for _, item := range list {
+			itemMap, ok := item.(map[string]interface{})
+			if !ok {
+				return names, false
+			}
+			name, ok := itemMap["name"].(string)
+			if !ok || unique[name] {
+				return names, false
+			}
+			unique[name] = true
+			names[listIdx] = append(names[listIdx], name)
+		}
+	}
+	return names, true
+}
+
+// joinKey joins the key to the path, keys with dots or brackets are escaped like the set paths.
+func joinKey(path, key string) string {
+	if strings.ContainsAny(key, ".[]") {
+		key = "[" + key + "]"
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
+
+func formatValue(value interface{}) string {
+	if value == nil {
+		return "null"
+	}
+	content, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(content)
+}
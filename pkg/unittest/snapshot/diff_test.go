@@ -0,0 +1,102 @@
+package snapshot_test
+
+import (
+	"testing"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStructuralDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		actual   string
+		changes  []string
+	}{
+		{
+			name:     "changed scalars",
+			expected: "spec:\n  replicas: 1\n  paused: false\n",
+			actual:   "spec:\n  replicas: 2\n  paused: false\n",
+			changes:  []string{"spec.replicas: 1 -> 2"},
+		},
+		{
+			name:     "added and removed keys",
+			expected: "metadata:\n  labels:\n    app: a\n    tier: web\n",
+			actual:   "metadata:\n  labels:\n    app: a\n    version: \"1\"\n",
+			changes: []string{
+				`metadata.labels.tier: "web" -> <none>`,
+				`metadata.labels.version: <none> -> "1"`,
+			},
+		},
+		{
+			name:     "list items matched by name",
+			expected: "containers:\n  - name: app\n    image: a:1\n  - name: sidecar\n    image: s:1\n",
+			actual:   "containers:\n  - name: init\n    image: i:1\n  - name: app\n    image: a:2\n",
+			changes: []string{
+				`containers[0]: <none> -> {"image":"i:1","name":"init"}`,
+				"containers[1]: moved from [0]",
+				`containers[1].image: "a:1" -> "a:2"`,
+				`containers[1]: {"image":"s:1","name":"sidecar"} -> <none>`,
+			},
+		},
+		{
+			name:     "list items matched by index",
+			expected: "args:\n  - a\n  - b\n",
+			actual:   "args:\n  - a\n  - c\n  - d\n",
+			changes: []string{
+				`args[1]: "b" -> "c"`,
+				`args[2]: <none> -> "d"`,
+			},
+		},
+		{
+			name:     "escaped keys",
+			expected: "data:\n  tls.crt: a\n",
+			actual:   "data:\n  tls.crt: b\n",
+			changes:  []string{`data.[tls.crt]: "a" -> "b"`},
+		},
+		{
+			name:     "changed type",
+			expected: "value: 1\n",
+			actual:   "value:\n  nested: 1\n",
+			changes:  []string{`value: 1 -> {"nested":1}`},
+		},
+		{
+			name:     "scalar document",
+			expected: "a\n",
+			actual:   "b\n",
+			changes:  []string{`.: "a" -> "b"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := StructuralDiff(tt.expected, tt.actual)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.changes, changes)
+		})
+	}
+}
+
+func TestStructuralDiffWhenNoStructuralChanges(t *testing.T) {
+	_, err := StructuralDiff("a: 1\nb: 2\n", "b: 2\na: 1\n")
+	assert.Error(t, err)
+
+	_, err = StructuralDiff("a: [", "a: 1")
+	assert.Error(t, err)
+}
+
+func TestCacheWhenStructuralDiff(t *testing.T) {
+	a := assert.New(t)
+	cache := createCache(a, true)
+	cache.IsStructuralDiff = true
+	a.Nil(cache.RestoreFromFile())
+
+	result := cache.Compare(cache_before, 1, content1)
+	a.True(result.Passed)
+	a.Empty(result.Diff)
+
+	result = cache.Compare(cache_before, 2, contentNew)
+	a.False(result.Passed)
+	a.Equal("d: {\"e\":\"f\"} -> <none>\nx: <none> -> {\"y\":\"z\"}", result.Diff)
+}
@@ -10,15 +10,15 @@ import (
 const snapshotDirName = "__snapshot__"
 const snapshotFileExt = ".snap"
 
-// CreateSnapshotOfSuite retruns snapshot.Cache for suite file, create `__snapshot__` dir if not existed
-func CreateSnapshotOfSuite(path string, isUpdating bool) (*Cache, error) {
+// CreateSnapshotOfSuite retruns snapshot.Cache for suite file in the layout, create `__snapshot__` dir if not existed
+func CreateSnapshotOfSuite(path string, isUpdating bool, layout Layout) (*Cache, error) {
 	cacheDir := filepath.Join(filepath.Dir(path), snapshotDirName)
 	if err := ensureDir(cacheDir); err != nil {
 		return nil, err
 	}
-	cacheFileName := filepath.Base(path) + snapshotFileExt
 	cache := &Cache{
-		Filepath:   filepath.Join(cacheDir, cacheFileName),
+		Filepath:   SnapshotPath(path, layout),
+		Layout:     layout,
 		IsUpdating: isUpdating,
 	}
 
@@ -28,6 +28,39 @@ func CreateSnapshotOfSuite(path string, isUpdating bool) (*Cache, error) {
 	return cache, nil
 }
 
+// ConvertSnapshotOfSuite returns snapshot.Cache for suite file in the layout, restored from the snapshots of the
+// suite in the other layout. The snapshots in the other layout are removed when the cache is stored.
+func ConvertSnapshotOfSuite(path string, layout Layout) (*Cache, error) {
+	from, err := CreateSnapshotOfSuite(path, false, layout.other())
+	if err != nil {
+		return nil, err
+	}
+	if !from.Existed {
+		return CreateSnapshotOfSuite(path, false, layout)
+	}
+
+	return &Cache{
+		Filepath:    SnapshotPath(path, layout),
+		Layout:      layout,
+		cached:      from.cached,
+		documents:   from.documents,
+		convertFrom: from.Filepath,
+	}, nil
+}
+
+// SnapshotFilePath returns the snapshot file of the suite file
+func SnapshotFilePath(path string) string {
+	return filepath.Join(filepath.Dir(path), snapshotDirName, filepath.Base(path)+snapshotFileExt)
+}
+
+// SnapshotPath returns the snapshot file of the suite file, or the snapshot directory in the document layout
+func SnapshotPath(path string, layout Layout) string {
+	if layout == DocumentLayout {
+		return filepath.Join(filepath.Dir(path), snapshotDirName, filepath.Base(path))
+	}
+	return SnapshotFilePath(path)
+}
+
 func ensureDir(path string) error {
 	info, err := os.Stat(path)
 	if err != nil {
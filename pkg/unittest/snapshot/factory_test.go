@@ -11,8 +11,8 @@ import (
 
 func TestCreateSnapshotOfSuiteReturnCacheRight(t *testing.T) {
 	dir, _ := os.MkdirTemp("", "test")
-	cache, err := CreateSnapshotOfSuite(filepath.Join(dir, "my_test.yaml"), true)
-	cache2, err2 := CreateSnapshotOfSuite(filepath.Join(dir, "another_test.yaml"), false)
+	cache, err := CreateSnapshotOfSuite(filepath.Join(dir, "my_test.yaml"), true, FileLayout)
+	cache2, err2 := CreateSnapshotOfSuite(filepath.Join(dir, "another_test.yaml"), false, FileLayout)
 
 	a := assert.New(t)
 	a.Nil(err)
@@ -26,7 +26,7 @@ func TestCreateSnapshotOfSuiteReturnCacheRight(t *testing.T) {
 
 func TestCreateSnapshotOfSuiteWhenNoCacheDir(t *testing.T) {
 	dir, _ := os.MkdirTemp("", "test")
-	cache, _ := CreateSnapshotOfSuite(filepath.Join(dir, "service_test.yaml"), false)
+	cache, _ := CreateSnapshotOfSuite(filepath.Join(dir, "service_test.yaml"), false, FileLayout)
 
 	info, err := os.Stat(filepath.Join(dir, "__snapshot__"))
 
@@ -49,7 +49,7 @@ func TestCreateSnapshotOfSuiteWhenCacheDirExisted(t *testing.T) {
 	if dirErr != nil {
 		a.FailNow("Failed to create cache dir")
 	}
-	cache, _ := CreateSnapshotOfSuite(filepath.Join(dir, "service_test.yaml"), false)
+	cache, _ := CreateSnapshotOfSuite(filepath.Join(dir, "service_test.yaml"), false, FileLayout)
 
 	info, err := os.Stat(filepath.Join(dir, "__snapshot__"))
 
@@ -78,7 +78,7 @@ func TestCreateSnapshotOfSuiteWhenCacheFileExisted(t *testing.T) {
 	if fileErr != nil {
 		a.FailNow("Failed to create cache file")
 	}
-	cache, _ := CreateSnapshotOfSuite(filepath.Join(dir, "service_test.yaml"), false)
+	cache, _ := CreateSnapshotOfSuite(filepath.Join(dir, "service_test.yaml"), false, FileLayout)
 
 	info, err := os.Stat(filepath.Join(dir, "__snapshot__"))
 
@@ -0,0 +1,235 @@
+package snapshot
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+)
+
+// Layout the layout of the snapshot files of a test suite
+type Layout string
+
+const (
+	// FileLayout stores the snapshots of a test suite in one file, `__snapshot__/<suite>.snap`
+	FileLayout Layout = "file"
+	// DocumentLayout stores the snapshots of each test and document in a separate file,
+	// `__snapshot__/<suite>/<test>/<kind>-<name>.snap`
+	DocumentLayout Layout = "document"
+)
+
+// defaultDocumentFile the name of the snapshot file of the snapshots without document
+const defaultDocumentFile = "snapshots"
+
+// suiteHeaderPrefix the comment on the first line of a snapshot file, which records the test suite file owning it
+const suiteHeaderPrefix = "# suite: "
+
+// ParseLayout returns the layout by name, the file layout when name is empty
+func ParseLayout(name string) (Layout, error) {
+	switch Layout(name) {
+	case "", FileLayout:
+		return FileLayout, nil
+	case DocumentLayout:
+		return DocumentLayout, nil
+	}
+	return "", fmt.Errorf("unknown snapshot layout %q, expected %q or %q", name, FileLayout, DocumentLayout)
+}
+
+// other returns the layout to convert from
+func (l Layout) other() Layout {
+	if l == DocumentLayout {
+		return FileLayout
+	}
+	return DocumentLayout
+}
+
+// documentSnapshots the content of a snapshot file in the document layout
+type documentSnapshots struct {
+	Test      string          `yaml:"test"`
+	Document  string          `yaml:"document,omitempty"`
+	Snapshots snapshotsOfTest `yaml:"snapshots"`
+}
+
+// restoreDocuments reads the snapshot files in the directory of the suite,
+// returns the snapshots by test and the document of each snapshot.
+func restoreDocuments(dir string) (map[string]snapshotsOfTest, map[string]map[string]string, error) {
+	cached := map[string]snapshotsOfTest{}
+	documents := map[string]map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || filepath.Ext(path) != snapshotFileExt {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var file documentSnapshots
+		if err := common.YmlUnmarshal(string(content), &file); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if cached[file.Test] == nil {
+			cached[file.Test] = snapshotsOfTest{}
+			documents[file.Test] = map[string]string{}
+		}
+		for key, snapshot := range file.Snapshots {
+			cached[file.Test][key] = snapshot
+			documents[file.Test][key] = file.Document
+		}
+		return nil
+	})
+	return cached, documents, err
+}
+
+// documentFiles returns the content of the snapshot files in the document layout by the path relative to the
+// directory of the suite. The names are derived from the test and the document, and numbered when not unique.
+func documentFiles(snapshots map[string]snapshotsOfTest, documents map[string]map[string]string, suite string) (map[string][]byte, error) {
+	tests := make([]string, 0, len(snapshots))
+	for test := range snapshots {
+		tests = append(tests, test)
+	}
+	sort.Strings(tests)
+
+	files := map[string][]byte{}
+	testDirs := map[string]bool{}
+	for _, test := range tests {
+		testDir := common.UniqueName(common.FileNameOf(test), testDirs)
+
+		byDocument := map[string]snapshotsOfTest{}
+		for key, snapshot := range snapshots[test] {
+			document := documents[test][key]
+			if byDocument[document] == nil {
+				byDocument[document] = snapshotsOfTest{}
+			}
+			byDocument[document][key] = snapshot
+		}
+		names := make([]string, 0, len(byDocument))
+		for document := range byDocument {
+			names = append(names, document)
+		}
+		sort.Strings(names)
+
+		documentNames := map[string]bool{}
+		for _, document := range names {
+			name := common.FileNameOf(document)
+			if name == "" {
+				name = defaultDocumentFile
+			}
+			content, err := encodeYAML(documentSnapshots{Test: test, Document: document, Snapshots: byDocument[document]})
+			if err != nil {
+				return nil, err
+			}
+			files[filepath.Join(testDir, common.UniqueName(name, documentNames)+snapshotFileExt)] = withSuiteHeader(suite, content)
+		}
+	}
+	return files, nil
+}
+
+// storeDocuments writes the snapshot files to the directory of the suite, unchanged files are not written
+// and the files which are no longer part of the snapshots are removed.
+func storeDocuments(dir string, files map[string][]byte) error {
+	if err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		relative, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if _, ok := files[relative]; !ok {
+			return os.Remove(path)
+		}
+		return nil
+	}); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	for relative, content := range files {
+		path := filepath.Join(dir, relative)
+		if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, content) {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return err
+		}
+	}
+	return removeEmptyDirs(dir)
+}
+
+// removeEmptyDirs removes the directories of tests without snapshot files.
+func removeEmptyDirs(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		testDir := filepath.Join(dir, entry.Name())
+		if testEntries, err := os.ReadDir(testDir); err == nil && len(testEntries) == 0 {
+			if err := os.Remove(testDir); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// withSuiteHeader prefixes the content of the snapshot file with the comment recording the test suite file, when known.
+func withSuiteHeader(suite string, content []byte) []byte {
+	if suite == "" {
+		return content
+	}
+	return append([]byte(suiteHeaderPrefix+suite+"\n"), content...)
+}
+
+// RecordedSuite returns the test suite file recorded in the snapshot file, or in the first snapshot file of the
+// snapshot directory in the document layout. Snapshot files stored without the record return false.
+func RecordedSuite(snapshotPath string) (string, bool) {
+	file := snapshotPath
+	if info, err := os.Stat(snapshotPath); err != nil {
+		return "", false
+	} else if info.IsDir() {
+		file = ""
+		_ = filepath.WalkDir(snapshotPath, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || file != "" {
+				return fs.SkipAll
+			}
+			if !entry.IsDir() && filepath.Ext(path) == snapshotFileExt {
+				file = path
+			}
+			return nil
+		})
+		if file == "" {
+			return "", false
+		}
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", false
+	}
+	firstLine, _, _ := bytes.Cut(content, []byte("\n"))
+	suite, found := bytes.CutPrefix(firstLine, []byte(suiteHeaderPrefix))
+	if !found || len(suite) == 0 {
+		return "", false
+	}
+	return string(suite), true
+}
+
+func encodeYAML(value interface{}) ([]byte, error) {
+	byteBuffer := new(bytes.Buffer)
+	yamlEncoder := common.YamlNewEncoder(byteBuffer)
+	yamlEncoder.SetIndent(common.YAMLINDENTION)
+	if err := yamlEncoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return byteBuffer.Bytes(), nil
+}
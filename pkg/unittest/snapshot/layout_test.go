@@ -0,0 +1,135 @@
+package snapshot_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLayout(t *testing.T) {
+	for name, expected := range map[string]Layout{"": FileLayout, "file": FileLayout, "document": DocumentLayout} {
+		layout, err := ParseLayout(name)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, layout)
+	}
+
+	_, err := ParseLayout("tree")
+	assert.EqualError(t, err, `unknown snapshot layout "tree", expected "file" or "document"`)
+}
+
+func TestSnapshotPath(t *testing.T) {
+	suite := filepath.Join("tests", "service_test.yaml")
+	assert.Equal(t, filepath.Join("tests", "__snapshot__", "service_test.yaml.snap"), SnapshotPath(suite, FileLayout))
+	assert.Equal(t, filepath.Join("tests", "__snapshot__", "service_test.yaml"), SnapshotPath(suite, DocumentLayout))
+}
+
+func TestCacheWhenDocumentLayout(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "service_test.yaml")
+	compare := func(cache *Cache) {
+		assert.True(t, cache.Compare("should render", 1, content1, WithName("a"), WithDocument("Service/my-app")).Passed)
+		assert.True(t, cache.Compare("should render", 2, content2, WithName("d"), WithDocument("Service/my-app")).Passed)
+		assert.True(t, cache.Compare("should render", 3, contentNew, WithName("x"), WithDocument("Deployment/my-app")).Passed)
+		assert.True(t, cache.Compare("should render!", 1, contentNew).Passed)
+	}
+
+	cache := &Cache{Filepath: dir, Layout: DocumentLayout}
+	assert.NoError(t, cache.RestoreFromFile())
+	assert.False(t, cache.Existed)
+	compare(cache)
+	stored, err := cache.StoreToFileIfNeeded()
+	assert.NoError(t, err)
+	assert.True(t, stored)
+
+	content, err := os.ReadFile(filepath.Join(dir, "should-render", "Service-my-app.snap"))
+	assert.NoError(t, err)
+	assert.Equal(t, "test: should render\ndocument: Service/my-app\nsnapshots:\n  a: |\n    a:\n      b: c\n  d: |\n    d:\n      e: f\n", string(content))
+	content, err = os.ReadFile(filepath.Join(dir, "should-render", "Deployment-my-app.snap"))
+	assert.NoError(t, err)
+	assert.Equal(t, "test: should render\ndocument: Deployment/my-app\nsnapshots:\n  x: |\n    x:\n      \"y\": z\n", string(content))
+	// The names of the tests are unique, the documents without name are stored together.
+	content, err = os.ReadFile(filepath.Join(dir, "should-render-2", "snapshots.snap"))
+	assert.NoError(t, err)
+	assert.Equal(t, "test: should render!\nsnapshots:\n  1: |\n    x:\n      \"y\": z\n", string(content))
+
+	restored := &Cache{Filepath: dir, Layout: DocumentLayout}
+	assert.NoError(t, restored.RestoreFromFile())
+	assert.True(t, restored.Existed)
+	compare(restored)
+	assert.False(t, restored.Changed())
+
+	// The snapshot files of vanished snapshots are removed when pruning.
+	pruned := &Cache{Filepath: dir, Layout: DocumentLayout, IsPruning: true}
+	assert.NoError(t, pruned.RestoreFromFile())
+	assert.True(t, pruned.Compare("should render", 1, content1, WithName("a"), WithDocument("Service/my-app")).Passed)
+	_, err = pruned.StoreToFileIfNeeded()
+	assert.NoError(t, err)
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	entries, err = os.ReadDir(filepath.Join(dir, "should-render"))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestRecordedSuite(t *testing.T) {
+	for _, layout := range []Layout{FileLayout, DocumentLayout} {
+		path := SnapshotPath(filepath.Join(t.TempDir(), "service_test.yaml_v2"), layout)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+
+		cache := &Cache{Filepath: path, Layout: layout}
+		assert.True(t, cache.Compare("should render", 1, content1, WithDocument("Service/my-app")).Passed)
+		_, err := cache.StoreToFileIfNeeded()
+		assert.NoError(t, err)
+		_, recorded := RecordedSuite(path)
+		assert.False(t, recorded, layout)
+
+		// The snapshots without recorded suite are rewritten when pruning.
+		restored := &Cache{Filepath: path, Layout: layout, Suite: "service_test.yaml", IsPruning: true}
+		assert.NoError(t, restored.RestoreFromFile())
+		assert.True(t, restored.Compare("should render", 1, content1, WithDocument("Service/my-app")).Passed)
+		stored, err := restored.StoreToFileIfNeeded()
+		assert.NoError(t, err)
+		assert.True(t, stored, layout)
+		suite, recorded := RecordedSuite(path)
+		assert.True(t, recorded, layout)
+		assert.Equal(t, "service_test.yaml", suite, layout)
+	}
+}
+
+func TestConvertSnapshotOfSuite(t *testing.T) {
+	dir := t.TempDir()
+	suite := filepath.Join(dir, "cache_test.yaml")
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "__snapshot__"), 0755))
+	assert.NoError(t, os.WriteFile(SnapshotFilePath(suite), []byte(lastTimeContent), 0644))
+
+	cache, err := ConvertSnapshotOfSuite(suite, DocumentLayout)
+	assert.NoError(t, err)
+	assert.True(t, cache.Compare(cache_before, 1, content1, WithDocument("Service/my-app")).Passed)
+	stored, err := cache.StoreToFileIfNeeded()
+	assert.NoError(t, err)
+	assert.True(t, stored)
+
+	// The snapshot which is not compared is kept without document.
+	_, err = os.Stat(SnapshotFilePath(suite))
+	assert.True(t, os.IsNotExist(err))
+	content, err := os.ReadFile(filepath.Join(SnapshotPath(suite, DocumentLayout), "cached-before", "Service-my-app.snap"))
+	assert.NoError(t, err)
+	assert.Equal(t, "test: cached before\ndocument: Service/my-app\nsnapshots:\n  1: |\n    a:\n      b: c\n", string(content))
+	content, err = os.ReadFile(filepath.Join(SnapshotPath(suite, DocumentLayout), "cached-before", "snapshots.snap"))
+	assert.NoError(t, err)
+	assert.Equal(t, "test: cached before\nsnapshots:\n  2: |\n    d:\n      e: f\n", string(content))
+
+	// Converting back restores the snapshot file.
+	cache, err = ConvertSnapshotOfSuite(suite, FileLayout)
+	assert.NoError(t, err)
+	_, err = cache.StoreToFileIfNeeded()
+	assert.NoError(t, err)
+	content, err = os.ReadFile(SnapshotFilePath(suite))
+	assert.NoError(t, err)
+	assert.Equal(t, lastTimeContent, string(content))
+	_, err = os.Stat(SnapshotPath(suite, DocumentLayout))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,181 @@
+package snapshot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+)
+
+// RedactedValue the placeholder of the redacted values
+const RedactedValue = "<redacted>"
+
+// Redaction the paths of the volatile fields of a document, which are removed (ignore) or replaced
+// with a placeholder (redact) before the snapshot is compared.
+// Paths are separated by dots, dots in keys are escaped like `data.tls\.crt`,
+// list items are selected by index like `containers[0]` and `*` selects all keys or items.
+type Redaction struct {
+	Ignore []string `yaml:"ignore"`
+	Redact []string `yaml:"redact"`
+}
+
+// Merge returns the paths of both redactions.
+func (r Redaction) Merge(other Redaction) Redaction {
+	return Redaction{
+		Ignore: append(append([]string{}, r.Ignore...), other.Ignore...),
+		Redact: append(append([]string{}, r.Redact...), other.Redact...),
+	}
+}
+
+// IsEmpty returns whether no paths are defined.
+func (r Redaction) IsEmpty() bool {
+	return len(r.Ignore) == 0 && len(r.Redact) == 0
+}
+
+// Apply returns a copy of the document with the paths removed or redacted, the document itself is left untouched.
+func (r Redaction) Apply(document map[string]interface{}) (map[string]interface{}, error) {
+	if r.IsEmpty() {
+		return document, nil
+	}
+
+	redacted, _ := copyValue(document).(map[string]interface{})
+	for _, path := range r.Ignore {
+		elements, err := parseRedactionPath(path)
+		if err != nil {
+			return nil, err
+		}
+		redactValue(redacted, elements, false)
+	}
+	for _, path := range r.Redact {
+		elements, err := parseRedactionPath(path)
+		if err != nil {
+			return nil, err
+		}
+		redactValue(redacted, elements, true)
+	}
+	return redacted, nil
+}
+
+// redactionPathElement a key of a map, or the index of a list item
+type redactionPathElement struct {
+	key   string
+	index int
+	isAny bool
+	isKey bool
+}
+
+func parseRedactionPath(path string) ([]redactionPathElement, error) {
+	elements := make([]redactionPathElement, 0)
+	var key strings.Builder
+	addKey := func() {
+		value := key.String()
+		elements = append(elements, redactionPathElement{key: value, isAny: value == "*", isKey: true})
+		key.Reset()
+	}
+
+	for idx := 0; idx < len(path); idx++ {
+		switch character := path[idx]; character {
+		case '\\':
+			if idx+1 < len(path) {
+				idx++
+				key.WriteByte(path[idx])
+			}
+		case '.':
+			if key.Len() > 0 {
+				addKey()
+			} else if idx == 0 || path[idx-1] != ']' {
+				return nil, fmt.Errorf("invalid redaction path %s: empty key", path)
+			}
+		case '[':
+			if key.Len() > 0 {
+				addKey()
+			}
+			end := strings.IndexByte(path[idx:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid redaction path %s: missing ]", path)
+			}
+			index := path[idx+1 : idx+end]
+			if index == "*" {
+				elements = append(elements, redactionPathElement{isAny: true})
+			} else {
+				value, err := strconv.Atoi(index)
+				if err != nil {
+					return nil, fmt.Errorf("invalid redaction path %s: %w", path, err)
+				}
+				elements = append(elements, redactionPathElement{index: value})
+			}
+			idx += end
+		default:
+			key.WriteByte(character)
+		}
+	}
+	if key.Len() > 0 {
+		addKey()
+	}
+	if len(elements) == 0 {
+		return nil, fmt.Errorf("invalid redaction path %s: empty path", path)
+	}
+	return elements, nil
+}
+
+// redactValue removes or replaces the values at the path, missing paths are skipped.
+func redactValue(value interface{}, elements []redactionPathElement, replace bool) {
+	element, last := elements[0], len(elements) == 1
+
+	switch current := value.(type) {
+	case map[string]interface{}:
+		if !element.isKey {
+			return
+		}
+		for key := range current {
+			if !element.isAny && key != element.key {
+				continue
+			}
+			if !last {
+				redactValue(current[key], elements[1:], replace)
+			} else if replace {
+				current[key] = RedactedValue
+			} else {
+				delete(current, key)
+			}
+		}
+	case []interface{}:
+		if element.isKey && !element.isAny {
+			return
+		}
+		for idx := range current {
+			if !element.isAny && idx != element.index {
+				continue
+			}
+			if !last {
+				redactValue(current[idx], elements[1:], replace)
+			} else {
+				// Items are replaced instead of removed, to keep the index of the other items.
+				current[idx] = RedactedValue
+			}
+		}
+	}
+}
+
+// copyValue copies the maps and lists of the value, nested documents are copied as plain maps.
+func copyValue(value interface{}) interface{} {
+	switch current := value.(type) {
+	case common.K8sManifest:
+		return copyValue(map[string]interface{}(current))
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(current))
+		for key, item := range current {
+			copied[key] = copyValue(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(current))
+		for idx, item := range current {
+			copied[idx] = copyValue(item)
+		}
+		return copied
+	default:
+		return value
+	}
+}
@@ -0,0 +1,115 @@
+package snapshot_test
+
+import (
+	"testing"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactionApply(t *testing.T) {
+	document := func() map[string]interface{} {
+		return map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]interface{}{
+					"checksum/config": "abc",
+					"team":            "a",
+				},
+			},
+			"data": map[string]interface{}{
+				"tls.crt": "cert",
+				"tls.key": "key",
+			},
+			"containers": []interface{}{
+				map[string]interface{}{"name": "app", "image": "app:1"},
+				map[string]interface{}{"name": "sidecar", "image": "sidecar:1"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		redaction Redaction
+		expected  map[string]interface{}
+	}{
+		{
+			name:      "ignore key with slash",
+			redaction: Redaction{Ignore: []string{"metadata.annotations.checksum/config"}},
+			expected: func() map[string]interface{} {
+				expected := document()
+				delete(expected["metadata"].(map[string]interface{})["annotations"].(map[string]interface{}), "checksum/config")
+				return expected
+			}(),
+		},
+		{
+			name:      "redact escaped key",
+			redaction: Redaction{Redact: []string{`data.tls\.crt`}},
+			expected: func() map[string]interface{} {
+				expected := document()
+				expected["data"].(map[string]interface{})["tls.crt"] = RedactedValue
+				return expected
+			}(),
+		},
+		{
+			name:      "redact all items",
+			redaction: Redaction{Redact: []string{"containers[*].image"}},
+			expected: func() map[string]interface{} {
+				expected := document()
+				for _, container := range expected["containers"].([]interface{}) {
+					container.(map[string]interface{})["image"] = RedactedValue
+				}
+				return expected
+			}(),
+		},
+		{
+			name:      "ignore list item keeps index",
+			redaction: Redaction{Ignore: []string{"containers[0]"}},
+			expected: func() map[string]interface{} {
+				expected := document()
+				expected["containers"].([]interface{})[0] = RedactedValue
+				return expected
+			}(),
+		},
+		{
+			name:      "redact all keys",
+			redaction: Redaction{Redact: []string{"data.*"}},
+			expected: func() map[string]interface{} {
+				expected := document()
+				expected["data"] = map[string]interface{}{"tls.crt": RedactedValue, "tls.key": RedactedValue}
+				return expected
+			}(),
+		},
+		{
+			name:      "missing paths are skipped",
+			redaction: Redaction{Ignore: []string{"spec.replicas", "metadata[0]", "containers.name"}},
+			expected:  document(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := document()
+			redacted, err := tt.redaction.Apply(original)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, redacted)
+			assert.Equal(t, document(), original)
+		})
+	}
+}
+
+func TestRedactionApplyWhenInvalidPath(t *testing.T) {
+	for _, path := range []string{"", ".metadata", "containers[a]", "containers[0"} {
+		_, err := Redaction{Ignore: []string{path}}.Apply(map[string]interface{}{})
+		assert.Error(t, err, path)
+	}
+}
+
+func TestRedactionMerge(t *testing.T) {
+	suite := Redaction{Ignore: []string{"a"}, Redact: []string{"b"}}
+	merged := suite.Merge(Redaction{Redact: []string{"c"}})
+
+	assert.Equal(t, Redaction{Ignore: []string{"a"}, Redact: []string{"b", "c"}}, merged)
+	assert.Equal(t, Redaction{Ignore: []string{"a"}, Redact: []string{"b"}}, suite)
+	assert.True(t, Redaction{}.IsEmpty())
+}
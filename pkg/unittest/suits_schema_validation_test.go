@@ -128,6 +128,27 @@ func TestValidateExampleChartsWithTestSuitsAgainstLocalSchema(t *testing.T) {
 		{
 			testsPath: "../../test/data/v3/library-chart/tests/chart/tests/unit",
 		},
+		{
+			testsPath: "testdata/chart-upgrade/tests",
+		},
+		{
+			testsPath: "testdata/chart-deterministic/tests",
+		},
+		{
+			testsPath: "testdata/chart-mocks/tests",
+		},
+		{
+			testsPath: "testdata/chart-k8s-provider/tests",
+		},
+		{
+			testsPath: "testdata/chart-snapshot/tests/names",
+		},
+		{
+			testsPath: "testdata/chart-snapshot/tests/redaction",
+		},
+		{
+			testsPath: "testdata/chart-snapshot/tests/inline",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,97 @@
+package unittest
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+)
+
+// tplTemplateName the name of the template of the tpl function
+const tplTemplateName = "gotpl"
+
+// schemaErrorPrefix the start of the error of values which do not meet the schema of the chart
+const schemaErrorPrefix = "values don't meet the specifications of the schema(s)"
+
+var (
+	// helmErrorPattern matches the errors of fail and required, and the parse errors, which are cleaned up by helm
+	helmErrorPattern = regexp.MustCompile(`(?s)^(?:execution|parse) error (?:at|in) \((.+?)\): (.*)$`)
+	// executingPattern matches the location of each template in the chain of a template error
+	executingPattern = regexp.MustCompile(`template: ([^\s:]+):(\d+)(?::(\d+))?: executing "[^"]*" at <.*?>: `)
+	// functionPattern matches the functions in the chain of a template error, like include and tpl
+	functionPattern = regexp.MustCompile(`error calling (\w+): `)
+	// tplExecutionPattern matches the content of the tpl function in the chain of a template error
+	tplExecutionPattern = regexp.MustCompile(`^error during tpl function execution for ".*?": `)
+	// locationPattern splits the location `file:line:column` of helm errors, the line and column are optional
+	locationPattern = regexp.MustCompile(`^(.+?)(?::(\d+))?(?::(\d+))?$`)
+)
+
+// parseTemplateError returns the structured error of a failed render, nil when the render succeeded.
+// The errors of fail and required are cleaned up by helm, which keeps the location of the outer template only.
+func parseTemplateError(err error) *validators.TemplateError {
+	if err == nil {
+		return nil
+	}
+	message := err.Error()
+	templateError := &validators.TemplateError{Message: message}
+
+	if strings.HasPrefix(message, schemaErrorPrefix) {
+		for _, line := range strings.Split(message, "\n") {
+			if violation, found := strings.CutPrefix(line, "- "); found {
+				templateError.SchemaErrors = append(templateError.SchemaErrors, violation)
+			}
+		}
+		return templateError
+	}
+
+	if match := helmErrorPattern.FindStringSubmatch(message); match != nil {
+		setTemplateErrorLocation(templateError, match[1])
+		templateError.Message = match[2]
+		return templateError
+	}
+
+	executions := executingPattern.FindAllStringSubmatchIndex(message, -1)
+	for _, execution := range executions {
+		template := message[execution[2]:execution[3]]
+		if template == tplTemplateName {
+			continue
+		}
+		templateError.Template = template
+		templateError.Line, _ = strconv.Atoi(message[execution[4]:execution[5]])
+		templateError.Column = 0
+		if execution[6] >= 0 {
+			templateError.Column, _ = strconv.Atoi(message[execution[6]:execution[7]])
+		}
+	}
+	for _, function := range functionPattern.FindAllStringSubmatch(message, -1) {
+		templateError.Functions = append(templateError.Functions, function[1])
+	}
+	if len(executions) > 0 {
+		templateError.Message = innermostMessage(message[executions[len(executions)-1][1]:])
+	}
+	return templateError
+}
+
+// setTemplateErrorLocation sets the template, line and column of the location `file:line:column`.
+func setTemplateErrorLocation(templateError *validators.TemplateError, location string) {
+	match := locationPattern.FindStringSubmatch(location)
+	templateError.Template = match[1]
+	templateError.Line, _ = strconv.Atoi(match[2])
+	templateError.Column, _ = strconv.Atoi(match[3])
+}
+
+// innermostMessage strips the function calls from the message of the innermost template.
+func innermostMessage(message string) string {
+	for {
+		if match := functionPattern.FindStringIndex(message); match != nil && match[0] == 0 {
+			message = message[match[1]:]
+			continue
+		}
+		if match := tplExecutionPattern.FindStringIndex(message); match != nil {
+			message = message[match[1]:]
+			continue
+		}
+		return message
+	}
+}
@@ -0,0 +1,70 @@
+package unittest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTemplateError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      string
+		expected *validators.TemplateError
+	}{
+		{
+			name: "fail",
+			err:  "execution error at (basic/templates/deployment.yaml:2:8): line1\nline2",
+			expected: &validators.TemplateError{
+				Template: "basic/templates/deployment.yaml", Line: 2, Column: 8, Message: "line1\nline2",
+			},
+		},
+		{
+			name: "parse error",
+			err:  "parse error at (basic/templates/deployment.yaml:2): unclosed action",
+			expected: &validators.TemplateError{
+				Template: "basic/templates/deployment.yaml", Line: 2, Message: "unclosed action",
+			},
+		},
+		{
+			name: "include",
+			err: `template: basic/templates/deployment.yaml:1:6: executing "basic/templates/deployment.yaml" at <include "basic.name" .>: ` +
+				`error calling include: template: basic/templates/_helpers.tpl:2:14: executing "basic.name" at <.Values.a.b>: nil pointer evaluating interface {}.b`,
+			expected: &validators.TemplateError{
+				Template: "basic/templates/_helpers.tpl", Line: 2, Column: 14, Functions: []string{"include"},
+				Message: "nil pointer evaluating interface {}.b",
+			},
+		},
+		{
+			name: "tpl",
+			err: `template: basic/templates/deployment.yaml:1:6: executing "basic/templates/deployment.yaml" at <tpl "{{ .Values.a.b }}" .>: ` +
+				`error calling tpl: error during tpl function execution for "{{ .Values.a.b }}": template: gotpl:1:10: executing "gotpl" at <.Values.a.b>: nil pointer evaluating interface {}.b`,
+			expected: &validators.TemplateError{
+				Template: "basic/templates/deployment.yaml", Line: 1, Column: 6, Functions: []string{"tpl"},
+				Message: "nil pointer evaluating interface {}.b",
+			},
+		},
+		{
+			name: "schema",
+			err:  "values don't meet the specifications of the schema(s) in the following chart(s):\nbasic:\n- (root): name is required\n- replicas: Invalid type. Expected: integer, given: string\n",
+			expected: &validators.TemplateError{
+				Message:      "values don't meet the specifications of the schema(s) in the following chart(s):\nbasic:\n- (root): name is required\n- replicas: Invalid type. Expected: integer, given: string\n",
+				SchemaErrors: []string{"(root): name is required", "replicas: Invalid type. Expected: integer, given: string"},
+			},
+		},
+		{
+			name:     "unknown",
+			err:      "release name is too long",
+			expected: &validators.TemplateError{Message: "release name is too long"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseTemplateError(errors.New(tt.err)))
+		})
+	}
+	assert.Nil(t, parseTemplateError(nil))
+}
@@ -3,27 +3,30 @@ package unittest
 import (
 	"bytes"
 	"cmp"
-	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
+	"text/template"
 	"time"
 
 	"helm.sh/helm/v3/pkg/postrender"
 
 	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/helm-unittest/helm-unittest/internal/engine"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/results"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
 	log "github.com/sirupsen/logrus"
 
 	v3chart "helm.sh/helm/v3/pkg/chart"
 	v3util "helm.sh/helm/v3/pkg/chartutil"
-	v3engine "helm.sh/helm/v3/pkg/engine"
 )
 
 const LOG_TEST_JOB = "test-job"
@@ -139,32 +142,58 @@ func parseTextFile(rendered string) []common.K8sManifest {
 	return manifests
 }
 
+// writeRenderedOutput writes the rendered output to the render path, replacing the output of the previous run.
+// The written files are listed in the render path, so only these files are replaced.
 func writeRenderedOutput(renderPath string, outputOfFiles map[string]string) error {
-	if renderPath != "" {
-		for file, rendered := range outputOfFiles {
-			filePath := filepath.Join(renderPath, file)
-			directory := filepath.Dir(filePath)
-			if _, dirErr := os.Stat(directory); errors.Is(dirErr, os.ErrNotExist) {
-				if createDirErr := os.MkdirAll(directory, 0755); createDirErr != nil {
-					return createDirErr
-				}
-			}
-			if createFileErr := os.WriteFile(filePath, []byte(rendered), 0644); createFileErr != nil {
-				return createFileErr
-			}
+	if renderPath == "" {
+		return nil
+	}
+	if err := removeRenderedOutput(renderPath, renderPath); err != nil {
+		return err
+	}
+
+	files := make([]string, 0, len(outputOfFiles))
+	for file, rendered := range outputOfFiles {
+		if !filepath.IsLocal(filepath.FromSlash(file)) {
+			return fmt.Errorf("rendered file %s is outside the render path", file)
+		}
+		filePath := filepath.Join(renderPath, file)
+		if createDirErr := os.MkdirAll(filepath.Dir(filePath), 0755); createDirErr != nil {
+			return createDirErr
 		}
+		if createFileErr := os.WriteFile(filePath, []byte(rendered), 0644); createFileErr != nil {
+			return createFileErr
+		}
+		files = append(files, filepath.ToSlash(file))
 	}
-	return nil
+	return writeRenderedOutputList(renderPath, files)
 }
 
 type orderedSnapshotComparer struct {
 	cache   *snapshot.Cache
 	test    string
 	counter uint
+	names   map[string]uint
 }
 
+// CompareToSnapshot compares the content to the snapshot of the test, named snapshots which are compared
+// more than once in the test are numbered.
 func (s *orderedSnapshotComparer) CompareToSnapshot(content interface{}, optFns ...func(options *snapshot.CacheOptions) error) *snapshot.CompareResult {
 	s.counter++
+
+	var options snapshot.CacheOptions
+	for _, optFn := range optFns {
+		_ = optFn(&options)
+	}
+	if options.Name != "" {
+		if s.names == nil {
+			s.names = map[string]uint{}
+		}
+		s.names[options.Name]++
+		if count := s.names[options.Name]; count > 1 {
+			optFns = append(optFns, snapshot.WithName(fmt.Sprintf("%s #%d", options.Name, count)))
+		}
+	}
 	return s.cache.Compare(s.test, s.counter, content, optFns...)
 }
 
@@ -182,6 +211,8 @@ type TestJob struct {
 	Name             string `yaml:"it"`
 	Values           []string
 	Set              map[string]interface{}
+	SetValues        `yaml:",inline"`
+	Unset            []string
 	Template         string
 	Templates        []string
 	DocumentIndex    *int `yaml:"documentIndex"`
@@ -191,7 +222,8 @@ type TestJob struct {
 		Name      string
 		Namespace string
 		Revision  int
-		IsUpgrade bool `yaml:"upgrade"`
+		// IsUpgrade nil when not set, to use the setting of the suite
+		IsUpgrade *bool `yaml:"upgrade"`
 	}
 	Chart struct {
 		Version    string
@@ -205,21 +237,43 @@ type TestJob struct {
 	} `yaml:"skip"`
 	KubernetesProvider KubernetesFakeClientProvider `yaml:"kubernetesProvider"`
 	PostRendererConfig PostRendererConfig           `yaml:"postRenderer"`
+	UpgradeFrom        *UpgradeFromConfig           `yaml:"upgradeFrom"`
+	Functions          *FunctionStubs               `yaml:"functions"`
+	Mocks              *Mocks                       `yaml:"mocks"`
+	Snapshot           *snapshot.Redaction          `yaml:"snapshot"`
 
 	// global set values
 	globalSet map[string]interface{}
+	// global setString, setFile, setJSON and setLiteral values
+	globalSetValues SetValues
+	// the variables of the suite, substituted in the values
+	vars map[string]string
+	// substitutes the placeholders in the values, when the suite declares vars or substituteEnv
+	substitutesVars bool
+	// validates the values against the schema, without rendering the templates
+	schemaOnly bool
+	// the values generated by the fuzz command, overridden by the values of the test
+	fuzzValues map[string]interface{}
+	// the change of the template or the rendered output applied by the mutate command
+	mutant *mutant
 	// route indicate which chart in the dependency hierarchy
 	// like "parant-chart", "parent-charts/charts/child-chart"
 	chartRoute string
 	// where the test suite file located
 	definitionFile string
+	// the index of the test in the test suite
+	index int
+	// the inline snapshots to write to the test suite file, shared with the test suite
+	inlineSnapshots map[inlineSnapshotPosition]string
 	// list of templates assertion should assert if not specified
 	defaultTemplatesToAssert []string
 	// list of templates assertion should skip assert
 	defaultTemplatesToSkip []string
 	// requireSuccess
 	requireRenderSuccess bool
-	config               TestConfig
+	// the structured error of the last render, nil when the render succeeded
+	templateError *validators.TemplateError
+	config        TestConfig
 }
 
 func (t *TestJob) WithConfig(config TestConfig) {
@@ -238,6 +292,14 @@ func (t *TestJob) configOrDefault() TestConfig {
 	return t.config
 }
 
+// snapshotRedaction returns the redactions of the snapshots, including the redactions of the suite.
+func (t *TestJob) snapshotRedaction() snapshot.Redaction {
+	if t.Snapshot == nil {
+		return snapshot.Redaction{}
+	}
+	return *t.Snapshot
+}
+
 // RunV3 render the chart and validate it with assertions in TestJob.
 func (t *TestJob) RunV3(
 	result *results.TestJobResult,
@@ -253,6 +315,7 @@ func (t *TestJob) RunV3(
 	}
 
 	outputOfFiles, renderSucceed, renderError := t.renderV3Chart([]byte(userValues))
+	t.mutant.applyToOutput(t.chartRoute, outputOfFiles)
 	writeError := writeRenderedOutput(t.configOrDefault().renderPath, outputOfFiles)
 	if writeError != nil {
 		result.ExecError = writeError
@@ -283,16 +346,38 @@ func (t *TestJob) RunV3(
 		return result
 	}
 
+	allManifestsOfFiles := manifestsOfFiles
+	if t.assertsAllTemplates() && !t.selectsAllTemplates() {
+		allManifestsOfFiles, err = t.renderAllTemplates([]byte(userValues))
+		if err != nil {
+			result.ExecError = err
+			return result
+		}
+	}
+
+	var previousManifestsOfFiles map[string][]common.K8sManifest
+	if t.UpgradeFrom != nil {
+		previousManifestsOfFiles, err = t.renderPrevious()
+		if err != nil {
+			result.ExecError = err
+			return result
+		}
+	}
+
 	snapshotComparer := &orderedSnapshotComparer{cache: t.configOrDefault().cache, test: t.Name}
 
 	assertionsConfig := AssertionConfig{
-		templatesResult:     manifestsOfFiles,
-		snapshotComparer:    snapshotComparer,
-		renderSucceed:       renderSucceed,
-		failFast:            t.configOrDefault().failFast,
-		didPostRender:       didPostRender,
-		renderError:         renderError,
-		isSkipEmptyTemplate: t.configOrDefault().isSkipEmptyTemplate,
+		templatesResult:         manifestsOfFiles,
+		allTemplatesResult:      allManifestsOfFiles,
+		previousTemplatesResult: previousManifestsOfFiles,
+		snapshotComparer:        snapshotComparer,
+		snapshotRedaction:       t.snapshotRedaction(),
+		renderSucceed:           renderSucceed,
+		failFast:                t.configOrDefault().failFast,
+		didPostRender:           didPostRender,
+		renderError:             renderError,
+		templateError:           t.templateError,
+		isSkipEmptyTemplate:     t.configOrDefault().isSkipEmptyTemplate,
 	}
 
 	result.Passed, result.AssertsResult = t.runAssertions(assertionsConfig)
@@ -304,6 +389,9 @@ func (t *TestJob) RunV3(
 func (t *TestJob) getUserValues() (string, error) {
 	base := map[string]interface{}{}
 	routes := spliteChartRoutes(t.chartRoute)
+	if t.fuzzValues != nil {
+		base = scopeValuesWithRoutes(routes, copySet(t.fuzzValues))
+	}
 
 	// Load and merge values files.
 	for _, specifiedPath := range t.Values {
@@ -320,7 +408,12 @@ func (t *TestJob) getUserValues() (string, error) {
 			return "", err
 		}
 
-		if err := common.YmlUnmarshal(string(byteArray), &value); err != nil {
+		content, err := t.substituteVars(string(byteArray))
+		if err != nil {
+			return "", fmt.Errorf("failed to substitute %s: %w", specifiedPath, err)
+		}
+
+		if err := common.YmlUnmarshal(content, &value); err != nil {
 			return "", fmt.Errorf("failed to parse %s: %s", specifiedPath, err)
 		}
 
@@ -328,69 +421,108 @@ func (t *TestJob) getUserValues() (string, error) {
 	}
 
 	// Merge global set values before merging the other set values
-	for path, values := range t.globalSet {
-		setMap, err := valueutils.BuildValueOfSetPath(values, path)
-		if err != nil {
-			return "", err
-		}
-
-		base = v3util.MergeTables(scopeValuesWithRoutes(routes, setMap), base)
+	base, err := t.mergeSetValues(base, routes, t.globalSet, t.globalSetValues)
+	if err != nil {
+		return "", err
 	}
-
-	for path, values := range t.Set {
-		setMap, err := valueutils.BuildValueOfSetPath(values, path)
-		if err != nil {
-			return "", err
-		}
-
-		base = v3util.MergeTables(scopeValuesWithRoutes(routes, setMap), base)
+	base, err = t.mergeSetValues(base, routes, t.Set, t.SetValues)
+	if err != nil {
+		return "", err
 	}
 	log.WithField(LOG_TEST_JOB, "get-user-values").Debug("values ", base)
 	return common.YmlMarshall(base)
 }
 
-// render the chart and return result map
-func (t *TestJob) renderV3Chart(userValues []byte) (map[string]string, bool, error) {
-	values, err := v3util.ReadValues(userValues)
-	if err != nil {
-		return nil, false, err
-	}
-	options := *t.releaseV3Option()
+// assertsAllTemplates returns true when an assertion validates the documents against the documents of all
+// templates of the chart.
+func (t *TestJob) assertsAllTemplates() bool {
+	return slices.ContainsFunc(t.Assertions, func(assertion *Assertion) bool {
+		return assertion != nil && allTemplatesAssertTypes[assertion.AssertType]
+	})
+}
 
-	// Check Release Name length
-	if t.Release.Name != "" {
-		err = v3util.ValidateReleaseName(t.Release.Name)
-		if err != nil {
-			return nil, false, err
-		}
+// selectsAllTemplates returns true when the templates of the suite and test select all templates of the chart.
+func (t *TestJob) selectsAllTemplates() bool {
+	return len(t.defaultTemplatesToSkip) == 0 && slices.Equal(t.defaultTemplatesToAssert, []string{multiWildcard})
+}
+
+// withAllTemplates returns a copy of the test job which renders all templates of the chart, without writing the
+// rendered output.
+func (t *TestJob) withAllTemplates() *TestJob {
+	all := *t
+	all.defaultTemplatesToAssert = []string{multiWildcard}
+	all.defaultTemplatesToSkip = nil
+	all.config.renderPath = ""
+	return &all
+}
+
+// renderAllTemplates renders all templates of the chart, also the templates which are not selected by the templates
+// of the suite and test, and returns the documents by template.
+func (t *TestJob) renderAllTemplates(userValues []byte) (map[string][]common.K8sManifest, error) {
+	all := t.withAllTemplates()
+	outputOfFiles, _, err := all.renderV3Chart(userValues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render all templates: %w", err)
 	}
+	all.mutant.applyToOutput(all.chartRoute, outputOfFiles)
 
-	err = v3util.ProcessDependenciesWithMerge(t.configOrDefault().targetChart, values)
+	postRenderedManifestsOfFiles, _, err := all.postRender(outputOfFiles)
 	if err != nil {
-		return nil, false, err
+		return nil, fmt.Errorf("failed to render all templates: %w", err)
 	}
+	return all.parseManifestsFromOutputOfFiles(postRenderedManifestsOfFiles)
+}
 
-	vals, err := v3util.ToRenderValuesWithSchemaValidation(t.configOrDefault().targetChart, values.AsMap(), options, t.capabilitiesV3(), false)
+// render the chart and return result map
+func (t *TestJob) renderV3Chart(userValues []byte) (map[string]string, bool, error) {
+	vals, err := t.renderValuesV3(userValues)
+	t.templateError = parseTemplateError(err)
 	if err != nil {
 		return nil, false, err
 	}
+	if t.schemaOnly {
+		return map[string]string{}, true, nil
+	}
 	// When defaultTemplatesToAssert is empty, ensure all templates will be validated.
 	if len(t.defaultTemplatesToAssert) == 0 {
 		// Set all files
 		t.defaultTemplatesToAssert = []string{multiWildcard}
 	}
 
+	t.mutant.applyToChart(t.configOrDefault().targetChart)
+
 	// Filter the files that needs to be validated
 	filteredChart := CopyV3Chart(t.chartRoute, t.configOrDefault().targetChart.Name(), t.defaultTemplatesToAssert, t.defaultTemplatesToSkip, t.configOrDefault().targetChart)
 
-	var outputOfFiles map[string]string
+	// Override the non-deterministic functions when requested, the mocked functions take precedence.
+	funcs := template.FuncMap{}
+	if t.Functions != nil {
+		stubber, stubErr := newFunctionStubber(t.Functions)
+		if stubErr != nil {
+			return nil, false, stubErr
+		}
+		maps.Copy(funcs, stubber.funcMap())
+	}
+	if t.Mocks != nil {
+		mockedFuncs, mockErr := t.Mocks.mockV3Chart(filteredChart)
+		if mockErr != nil {
+			return nil, false, mockErr
+		}
+		maps.Copy(funcs, mockedFuncs)
+	}
+
 	// modify chart metadata before rendering
 	t.ModifyChartMetadata(t.configOrDefault().targetChart)
-	if len(t.KubernetesProvider.Objects) > 0 {
-		outputOfFiles, err = v3engine.RenderWithClientProvider(filteredChart, vals, &t.KubernetesProvider)
-	} else {
-		outputOfFiles, err = v3engine.Render(filteredChart, vals)
+	renderer := engine.Engine{}
+	if t.KubernetesProvider.enabled() {
+		if prepareErr := t.KubernetesProvider.prepare(t.configOrDefault().targetChart, filepath.Dir(t.definitionFile)); prepareErr != nil {
+			return nil, false, prepareErr
+		}
+		renderer = engine.NewWithClientProvider(&t.KubernetesProvider)
 	}
+	renderer.CustomTemplateFuncs = funcs
+	outputOfFiles, err := renderer.Render(filteredChart, vals)
+	t.templateError = parseTemplateError(err)
 
 	var renderSucceed bool
 	outputOfFiles, renderSucceed, err = t.translateErrorToOutputFiles(err, outputOfFiles)
@@ -401,6 +533,94 @@ func (t *TestJob) renderV3Chart(userValues []byte) (map[string]string, bool, err
 	return outputOfFiles, renderSucceed, nil
 }
 
+// renderValuesV3 returns the values to render the chart, the user values merged with the chart defaults and the
+// release and capabilities options.
+func (t *TestJob) renderValuesV3(userValues []byte) (v3util.Values, error) {
+	values, err := v3util.ReadValues(userValues)
+	if err != nil {
+		return nil, err
+	}
+	options := *t.releaseV3Option()
+
+	// Check Release Name length
+	if t.Release.Name != "" {
+		err = v3util.ValidateReleaseName(t.Release.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = v3util.ProcessDependenciesWithMerge(t.configOrDefault().targetChart, values)
+	if err != nil {
+		return nil, err
+	}
+
+	// The schema is validated after the unset values are removed.
+	vals, err := v3util.ToRenderValuesWithSchemaValidation(t.configOrDefault().targetChart, values.AsMap(), options, t.capabilitiesV3(), len(t.Unset) > 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(t.Unset) > 0 {
+		if err := t.unsetValues(vals); err != nil {
+			return nil, err
+		}
+	}
+	return vals, nil
+}
+
+// unsetValues removes the unset paths from the values of the chart, including the chart defaults, and validates the
+// remaining values against the schema. The paths are scoped to the chart of the test, the global paths are removed
+// from the globals of all charts.
+func (t *TestJob) unsetValues(vals v3util.Values) error {
+	chart := t.configOrDefault().targetChart
+	values, _ := vals["Values"].(v3util.Values)
+	if values == nil {
+		values = v3util.Values{}
+		vals["Values"] = values
+	}
+	scopedValues := values.AsMap()
+	for _, route := range spliteChartRoutes(t.chartRoute)[1:] {
+		next, _ := scopedValues[route].(map[string]interface{})
+		if next == nil {
+			next = map[string]interface{}{}
+		}
+		scopedValues = next
+	}
+
+	for _, path := range t.Unset {
+		if path == v3util.GlobalKey || strings.HasPrefix(path, v3util.GlobalKey+".") {
+			if err := unsetGlobalValues(chart, values.AsMap(), path); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := valueutils.UnsetValueOfSetPath(scopedValues, path); err != nil {
+			return err
+		}
+	}
+
+	if err := v3util.ValidateAgainstSchema(chart, values.AsMap()); err != nil {
+		return fmt.Errorf("values don't meet the specifications of the schema(s) in the following chart(s):\n%s", err.Error())
+	}
+	return nil
+}
+
+// unsetGlobalValues removes the global path from the values of the chart and the values of the subcharts,
+// which have a copy of the globals.
+func unsetGlobalValues(chart *v3chart.Chart, values map[string]interface{}, path string) error {
+	if err := valueutils.UnsetValueOfSetPath(values, path); err != nil {
+		return err
+	}
+	for _, dependency := range chart.Dependencies() {
+		if dependencyValues, ok := values[dependency.Name()].(map[string]interface{}); ok {
+			if err := unsetGlobalValues(dependency, dependencyValues, path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // MergeAndPostRender merge the map into a single file, post-render it, and split it out again
 func MergeAndPostRender(renderedManifestsMap map[string]string, postRenderer postrender.PostRenderer) (*bytes.Buffer, error) {
 	var renderedManifests bytes.Buffer
@@ -531,12 +751,13 @@ func (t *TestJob) translateErrorToOutputFiles(err error, outputOfFiles map[strin
 
 // get chartutil.ReleaseOptions ready for render
 func (t *TestJob) releaseV3Option() *v3util.ReleaseOptions {
+	isUpgrade := t.Release.IsUpgrade != nil && *t.Release.IsUpgrade
 	options := v3util.ReleaseOptions{
 		Name:      "RELEASE-NAME",
 		Namespace: "NAMESPACE",
 		Revision:  t.Release.Revision,
-		IsInstall: !t.Release.IsUpgrade,
-		IsUpgrade: t.Release.IsUpgrade,
+		IsInstall: !isUpgrade,
+		IsUpgrade: isUpgrade,
 	}
 	if t.Release.Name != "" {
 		options.Name = t.Release.Name
@@ -607,6 +828,11 @@ func (t *TestJob) runAssertions(
 			continue
 		}
 
+		cfg.inlineSnapshotComparer = &inlineSnapshotComparer{
+			cache:    t.configOrDefault().cache,
+			position: inlineSnapshotPosition{test: t.index, assertion: idx},
+			updates:  t.inlineSnapshots,
+		}
 		assertion.WithConfig(cfg)
 		result := assertion.Assert(
 			&results.AssertionResult{Index: idx},
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
 	"github.com/stretchr/testify/mock"
 
@@ -718,6 +720,103 @@ asserts:
 	a.Equal(1, len(testResult.AssertsResult))
 }
 
+func TestV3RunJobWithUnset(t *testing.T) {
+	c, _ := loader.Load(testV3BasicChart)
+	manifest := `
+it: should remove the chart defaults
+template: templates/deployment.yaml
+documentIndex: 0
+set:
+  resources:
+    limits:
+      cpu: 1
+unset:
+  - image.tag
+  - resources.limits
+asserts:
+  - equal:
+      path: spec.template.spec.containers[0].image
+      value: nginx:1.0.0
+  - equal:
+      path: spec.template.spec.containers[0].resources
+      value: {}
+`
+	var tj TestJob
+	common.YmlUnmarshalTestHelper(manifest, &tj, t)
+
+	tj.WithConfig(*NewTestConfig(c, &snapshot.Cache{}))
+	testResult := tj.RunV3(&results.TestJobResult{})
+
+	a := assert.New(t)
+	a.Nil(testResult.ExecError)
+	a.True(testResult.Passed, testResult.AssertsResult)
+}
+
+func TestV3RunJobWithUnsetRequiredBySchema(t *testing.T) {
+	c, _ := loader.Load(testV3WithSchemaChart)
+	manifest := `
+it: should validate the values without the unset values
+template: templates/dummy.yaml
+set:
+  image:
+    repository: "repo"
+    pullPolicy: IfNotPresent
+unset:
+  - image.pullPolicy
+asserts:
+  - failedTemplate: {}
+`
+	var tj TestJob
+	common.YmlUnmarshalTestHelper(manifest, &tj, t)
+
+	tj.WithConfig(*NewTestConfig(c, &snapshot.Cache{}))
+	testResult := tj.RunV3(&results.TestJobResult{})
+
+	a := assert.New(t)
+	a.ErrorContains(testResult.ExecError, "pullPolicy is required")
+}
+
+func TestV3RunnerWithUnsetInSubChart(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "with-subchart")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS(testV3WithSubChart)))
+	testsPath := filepath.Join(chartPath, "charts", "child-chart", "tests")
+	assert.NoError(t, os.RemoveAll(testsPath))
+	assert.NoError(t, os.Mkdir(testsPath, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(testsPath, "deployment_test.yaml"), []byte(`suite: unset in subchart
+templates:
+  - templates/deployment.yaml
+set:
+  global.namespace: my-namespace
+unset:
+  - image.tag
+tests:
+  - it: should remove the values of the subchart
+    asserts:
+      - equal:
+          path: spec.template.spec.containers[0].image
+          value: "nginx:"
+      - equal:
+          path: metadata.namespace
+          value: my-namespace
+  - it: should remove the globals
+    unset:
+      - global.namespace
+    asserts:
+      - equal:
+          path: metadata.namespace
+          value: RELEASE-NAME
+`), 0644))
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:      printer.NewPrinter(buffer, nil),
+		TestFiles:    []string{"tests/deployment_test.yaml"},
+		WithSubChart: true,
+	}
+	runner.RunV3([]string{chartPath})
+	assert.Contains(t, buffer.String(), " PASS  unset in subchart", buffer.String())
+}
+
 func TestV3RunSubChartWithVersionOverride(t *testing.T) {
 	c, _ := loader.Load(testV3WithSubChart)
 	manifest := `
@@ -1276,3 +1375,45 @@ asserts:
 	assert.True(t, testResult.Passed)
 	assert.Equal(t, 1, len(testResult.AssertsResult))
 }
+
+func TestV3RunnerWithFailedTemplateError(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "basic")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS(testV3BasicChart)))
+	assert.NoError(t, os.RemoveAll(filepath.Join(chartPath, "tests")))
+	assert.NoError(t, os.Mkdir(filepath.Join(chartPath, "tests"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "templates", "failing.yaml"), []byte(`apiVersion: v1
+kind: ConfigMap
+data:
+  name: {{ required "name is required" .Values.missing }}
+`), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "values.schema.json"), []byte(`{
+  "type": "object",
+  "properties": {"replicaCount": {"type": "integer"}}
+}`), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "failing_test.yaml"), []byte(`suite: failed template error
+templates:
+  - failing.yaml
+tests:
+  - it: should fail in the template
+    asserts:
+      - failedTemplate:
+          errorMessage: name is required
+          template: templates/failing.yaml
+          line: 4
+  - it: should fail on the schema
+    set:
+      replicaCount: two
+    asserts:
+      - failedTemplate:
+          schemaErrors:
+            - "replicaCount: Invalid type. Expected: integer, given: string"
+`), 0644))
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/*_test.yaml"},
+	}
+	assert.True(t, runner.RunV3([]string{chartPath}), buffer.String())
+	assert.Contains(t, buffer.String(), "Tests:       2 passed, 2 total")
+}
@@ -64,27 +64,44 @@ type testUnitCountingWithSnapshotFailed struct {
 // totalSnapshotCounting store testUnitCounting with snapshotFailed field
 type totalSnapshotCounting struct {
 	testUnitCounting
-	created  uint
-	vanished uint
+	created     uint
+	vanished    uint
+	pruned      uint
+	prunedFiles uint
 }
 
 // TestRunner stores basic settings and testing status for running all tests
 type TestRunner struct {
-	Printer          *printer.Printer
-	Formatter        formatter.Formatter
-	UpdateSnapshot   bool
-	WithSubChart     bool
-	Strict           bool
-	Failfast         bool
-	TestFiles        []string
-	ChartTestsPath   string
-	ValuesFiles      []string
-	OutputFile       string
-	RenderPath       string
+	Printer        *printer.Printer
+	Formatter      formatter.Formatter
+	UpdateSnapshot bool
+	PruneSnapshots bool
+	CI             bool
+	LineDiff       bool
+	WithSubChart   bool
+	Strict         bool
+	Failfast       bool
+	TestFiles      []string
+	ChartTestsPath string
+	ValuesFiles    []string
+	OutputFile     string
+	RenderPath     string
+	Deterministic  bool
+	// RenderDiff prints the rendered files in RenderPath which changed since the previous run
+	RenderDiff bool
+	// Reviewer decides on the changed and new snapshots, instead of failing the snapshots
+	Reviewer SnapshotReviewer
+	// SnapshotLayout the layout of the snapshot files, the file layout when empty
+	SnapshotLayout snapshot.Layout
+	// ConvertSnapshots stores the snapshots of the other layout in SnapshotLayout
+	ConvertSnapshots bool
+	// SuiteDefaults the settings of the project configuration, for the test suites which do not define them
+	SuiteDefaults    SuiteDefaults
 	suiteCounting    testUnitCountingWithSnapshotFailed
 	testCounting     testUnitCounting
 	chartCounting    testUnitCounting
 	snapshotCounting totalSnapshotCounting
+	reviewCounting   reviewCounting
 	testResults      []*results.TestSuiteResult
 }
 
@@ -92,6 +109,8 @@ type TestRunner struct {
 func (tr *TestRunner) RunV3(ChartPaths []string) bool {
 	allPassed := true
 	start := time.Now()
+	previousRender := tr.readPreviousRender()
+	chartRenderDirs := make(map[string]bool)
 	for _, chartPath := range ChartPaths {
 		chart, err := v3loader.Load(chartPath)
 		if err != nil {
@@ -115,8 +134,22 @@ func (tr *TestRunner) RunV3(ChartPaths []string) bool {
 			continue
 		}
 
+		if err := tr.checkRenderPath(chartPath, testSuites); err != nil {
+			tr.printErroredChartHeader(err)
+			tr.countChart(false, err)
+			allPassed = false
+			if tr.Failfast {
+				break
+			}
+			continue
+		}
+
 		tr.printChartHeader(chart.Name(), chartPath)
-		chartPassed := tr.runV3SuitesOfChart(testSuites, chartPath)
+		chartRenderPath, err := tr.clearChartRenderPath(chart.Name(), chartRenderDirs)
+		if err != nil {
+			tr.printErroredChartHeader(err)
+		}
+		chartPassed := tr.runV3SuitesOfChart(testSuites, chartPath, chartRenderPath)
 
 		tr.countChart(chartPassed, nil)
 		allPassed = allPassed && chartPassed
@@ -125,6 +158,7 @@ func (tr *TestRunner) RunV3(ChartPaths []string) bool {
 	if err != nil {
 		tr.printErroredChartHeader(err)
 	}
+	tr.printRenderDiff(previousRender)
 	tr.printSnapshotSummary()
 	tr.printSummary(time.Since(start))
 	return allPassed
@@ -174,6 +208,9 @@ func (tr *TestRunner) getTestSuites(chartPath, chartRoute string) ([]*TestSuite,
 		resultSuites = append(resultSuites, suites...)
 	}
 	resultSuites = append(resultSuites, renderedTestSuites...)
+	for _, suite := range resultSuites {
+		suite.polishDefaultSettings(tr.SuiteDefaults)
+	}
 	return resultSuites, nil
 }
 
@@ -208,10 +245,11 @@ func (tr *TestRunner) getV3TestSuites(chartPath, chartRoute string, chart *v3cha
 }
 
 // runV3SuitesOfChart runs suite files of the chart and print output
-func (tr *TestRunner) runV3SuitesOfChart(suites []*TestSuite, chartPath string) bool {
+func (tr *TestRunner) runV3SuitesOfChart(suites []*TestSuite, chartPath, chartRenderPath string) bool {
 	chartPassed := true
+	suiteRenderDirs := make(map[string]bool)
 	for _, suite := range suites {
-		snapshotCache, err := snapshot.CreateSnapshotOfSuite(suite.SnapshotFileUrl(), tr.UpdateSnapshot)
+		snapshotCache, err := tr.createSnapshotOfSuite(suite)
 		if err != nil {
 			tr.handleSuiteResult(&results.TestSuiteResult{
 				FilePath:  suite.definitionFile,
@@ -220,12 +258,22 @@ func (tr *TestRunner) runV3SuitesOfChart(suites []*TestSuite, chartPath string)
 			chartPassed = false
 			continue
 		}
-		result := suite.RunV3(chartPath, snapshotCache, tr.Failfast, tr.RenderPath, &results.TestSuiteResult{})
+		if tr.Deterministic && suite.Functions == nil {
+			suite.Functions = &FunctionStubs{}
+		}
+		snapshotCache.IsCI = tr.CI
+		snapshotCache.IsStructuralDiff = !tr.LineDiff
+		snapshotCache.IsReviewing = tr.Reviewer != nil
+		renderPath := suite.suiteRenderPath(chartRenderPath, chartPath, suiteRenderDirs)
+		result := suite.RunV3(chartPath, snapshotCache, tr.Failfast, renderPath, &results.TestSuiteResult{})
 		chartPassed = chartPassed && result.Passed
 		tr.handleSuiteResult(result)
 		tr.testResults = append(tr.testResults, result)
+		tr.pruneSnapshotsOfSuite(snapshotCache, result)
+		chartPassed = tr.reviewSnapshotsOfSuite(suite, snapshotCache) && chartPassed
 
 		_, storeErr := snapshotCache.StoreToFileIfNeeded()
+		storeErr = errors.Join(storeErr, suite.storeInlineSnapshots())
 		if storeErr != nil {
 			tr.handleSuiteResult(&results.TestSuiteResult{
 				FilePath:  suite.SnapshotFileUrl(),
@@ -239,9 +287,98 @@ func (tr *TestRunner) runV3SuitesOfChart(suites []*TestSuite, chartPath string)
 		}
 	}
 
+	if err := tr.pruneSnapshotFiles(suites); err != nil {
+		tr.printErroredChartHeader(err)
+		chartPassed = false
+	}
+
 	return chartPassed
 }
 
+// createSnapshotOfSuite returns the snapshot cache of the suite in the snapshot layout,
+// converted from the other layout when converting the snapshots.
+func (tr *TestRunner) createSnapshotOfSuite(suite *TestSuite) (*snapshot.Cache, error) {
+	layout, err := snapshot.ParseLayout(string(tr.SnapshotLayout))
+	if err != nil {
+		return nil, err
+	}
+
+	var cache *snapshot.Cache
+	if tr.ConvertSnapshots {
+		cache, err = snapshot.ConvertSnapshotOfSuite(suite.SnapshotFileUrl(), layout)
+	} else {
+		cache, err = snapshot.CreateSnapshotOfSuite(suite.SnapshotFileUrl(), tr.UpdateSnapshot, layout)
+	}
+	if err != nil {
+		return nil, err
+	}
+	cache.Suite = filepath.Base(suite.definitionFile)
+	return cache, nil
+}
+
+// pruneSnapshotsOfSuite removes the snapshots of the tests which no longer exist when pruning.
+// Only the snapshots of passed suites are pruned, the snapshots of skipped tests are kept.
+func (tr *TestRunner) pruneSnapshotsOfSuite(cache *snapshot.Cache, result *results.TestSuiteResult) {
+	if !tr.PruneSnapshots || !result.Passed || result.Skipped {
+		return
+	}
+	for _, testResult := range result.TestsResult {
+		if testResult != nil && testResult.Skipped {
+			cache.Retain(testResult.DisplayName)
+		}
+	}
+	cache.IsPruning = true
+	tr.snapshotCounting.pruned += cache.PrunedCount()
+}
+
+// pruneSnapshotFiles removes the snapshot files, or the snapshot directories in the document layout,
+// in the snapshot directories of the suites, of which the test suite file no longer exists.
+func (tr *TestRunner) pruneSnapshotFiles(suites []*TestSuite) error {
+	if !tr.PruneSnapshots {
+		return nil
+	}
+
+	snapshotFiles := map[string]bool{}
+	suiteDirs := map[string]bool{}
+	for _, suite := range suites {
+		snapshotFiles[snapshot.SnapshotPath(suite.SnapshotFileUrl(), tr.SnapshotLayout)] = true
+		suiteDirs[filepath.Dir(suite.definitionFile)] = true
+	}
+
+	for suiteDir := range suiteDirs {
+		files, err := filepath.Glob(snapshot.SnapshotPath(filepath.Join(suiteDir, "*"), tr.SnapshotLayout))
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			if snapshotFiles[file] || !ownerRemoved(suiteDir, file) {
+				continue
+			}
+			if info, err := os.Stat(file); err != nil || info.IsDir() != (tr.SnapshotLayout == snapshot.DocumentLayout) {
+				continue
+			}
+			if err := os.RemoveAll(file); err != nil {
+				return err
+			}
+			log.WithField(LOG_TEST_RUNNER, "prune-snapshot-files").Debug("removed obsolete snapshot file ", file)
+			tr.snapshotCounting.prunedFiles++
+		}
+	}
+	return nil
+}
+
+// ownerRemoved returns whether the test suite file recorded in the snapshot file no longer exists.
+// Snapshot files without the recorded suite are kept, they are recorded when the suite is pruned.
+func ownerRemoved(suiteDir, snapshotFile string) bool {
+	suite, recorded := snapshot.RecordedSuite(snapshotFile)
+	if !recorded {
+		log.WithField(LOG_TEST_RUNNER, "prune-snapshot-files").Debug("kept snapshot file without recorded suite ", snapshotFile)
+		return false
+	}
+	_, err := os.Stat(filepath.Join(suiteDir, suite))
+	return os.IsNotExist(err)
+}
+
 // handleSuiteResult print suite result and count suites and tests status
 func (tr *TestRunner) handleSuiteResult(result *results.TestSuiteResult) {
 	result.Print(tr.Printer, 0)
@@ -308,12 +445,31 @@ func (tr *TestRunner) printSnapshotSummary() {
 		snapshotFormat := `
 Snapshot Summary: %s`
 
+		hint := " Check changes and use `-u` to update snapshot."
 		summary := tr.Printer.Danger("%d snapshot failed", tr.snapshotCounting.failed) +
 			fmt.Sprintf(" in %d test suite.", tr.suiteCounting.snapshotFailed) +
-			tr.Printer.Faint("%s", " Check changes and use `-u` to update snapshot.")
+			tr.Printer.Faint("%s", hint)
 
 		tr.Printer.Println(fmt.Sprintf(snapshotFormat, summary), 0)
 	}
+
+	if tr.Reviewer != nil {
+		tr.Printer.Println(fmt.Sprintf(`
+Review Summary: %d snapshot accepted, %d rejected and %d skipped.`,
+			tr.reviewCounting.accepted, tr.reviewCounting.rejected, tr.reviewCounting.skipped), 0)
+		if tr.reviewCounting.rejected > 0 {
+			tr.Printer.Println(tr.Printer.Danger("%s", "The rejected snapshots fail the review, fix the chart or the tests."), 0)
+		}
+		if tr.reviewCounting.skipped > 0 {
+			tr.Printer.Println(tr.Printer.Faint("%s", "The skipped snapshots are not stored, review them again."), 0)
+		}
+	}
+
+	if tr.snapshotCounting.pruned > 0 || tr.snapshotCounting.prunedFiles > 0 {
+		tr.Printer.Println(fmt.Sprintf(`
+Snapshot Summary: %d obsolete snapshot and %d obsolete snapshot file removed.`,
+			tr.snapshotCounting.pruned, tr.snapshotCounting.prunedFiles), 0)
+	}
 }
 
 // countSuite count suite status and snapshot status
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/Masterminds/semver/v3"
@@ -40,10 +41,12 @@ func ParseTestSuiteFile(suiteFilePath, chartRoute string, strict bool, valueFile
 	parts := splitterPattern.Split(string(content), -1)
 	log.WithField(common.LOG_TEST_SUITE, "parse-test-suite-file").Debug("suite '", suiteFilePath, "' total parts ", len(parts))
 	var testSuites []*TestSuite
+	documentIndex := 0
 	for _, part := range parts {
 		if len(strings.TrimSpace(part)) > 0 {
 			testSuite, suiteErr := createTestSuite(suiteFilePath, chartRoute, part, strict, valueFilesSet, false)
 			if testSuite != nil {
+				testSuite.documentIndex = documentIndex
 				for _, test := range testSuite.Tests {
 					if test != nil {
 						testSuite.polishSkipSettings(test)
@@ -53,6 +56,7 @@ func ParseTestSuiteFile(suiteFilePath, chartRoute string, strict bool, valueFile
 				}
 				testSuites = append(testSuites, testSuite)
 			}
+			documentIndex++
 			if suiteErr != nil {
 				log.WithField(common.LOG_TEST_SUITE, "parse-test-suite-file").Debug("error '", suiteErr.Error(), "' strict ", strict)
 				return testSuites, suiteErr
@@ -209,13 +213,18 @@ type TestSuite struct {
 	Name             string `yaml:"suite"`
 	Values           []string
 	Set              map[string]interface{}
+	SetValues        `yaml:",inline"`
+	Unset            []string
+	Vars             map[string]string
+	SubstituteEnv    bool `yaml:"substituteEnv"`
 	Templates        []string
 	ExcludeTemplates []string `yaml:"excludeTemplates"`
 	Release          struct {
 		Name      string
 		Namespace string
 		Revision  int
-		IsUpgrade bool `yaml:"upgrade"`
+		// IsUpgrade nil when not set, to use the default of the project configuration
+		IsUpgrade *bool `yaml:"upgrade"`
 	}
 	Chart struct {
 		Version    string
@@ -228,6 +237,14 @@ type TestSuite struct {
 	}
 	KubernetesProvider KubernetesFakeClientProvider `yaml:"kubernetesProvider"`
 	PostRendererConfig PostRendererConfig           `yaml:"postRenderer"`
+	UpgradeFrom        *UpgradeFromConfig           `yaml:"upgradeFrom"`
+	Functions          *FunctionStubs               `yaml:"functions"`
+	Mocks              *Mocks                       `yaml:"mocks"`
+	Snapshot           *snapshot.Redaction          `yaml:"snapshot"`
+	// SchemaOnly validates the values against the schema of the chart, without rendering the templates
+	SchemaOnly bool `yaml:"schemaOnly"`
+	// Fuzz runs the tests of the suite with the values generated by the fuzz command
+	Fuzz bool `yaml:"fuzz"`
 
 	Tests []*TestJob
 	// where the test suite file located
@@ -237,6 +254,12 @@ type TestSuite struct {
 	chartRoute string
 	// if true, indicates that this was created from a helm rendered file
 	fromRender bool
+	// the index of the test suite in the test suite file
+	documentIndex int
+	// the missing and updated inline snapshots to write to the test suite file
+	inlineSnapshots map[inlineSnapshotPosition]string
+	// the change of the template or the rendered output applied by the mutate command
+	mutant *mutant
 	// An identifier to append to snapshot files
 	SnapshotId string `yaml:"snapshotId"`
 	Skip       struct {
@@ -266,6 +289,7 @@ func (s *TestSuite) RunV3(
 		failFast,
 		renderPath,
 	)
+	s.polishAssertionSources(r.JobResults)
 
 	result.Passed = r.Pass
 	result.FailFast = r.FailFast
@@ -279,22 +303,39 @@ func (s *TestSuite) RunV3(
 // fill file path related info of TestJob
 func (s *TestSuite) polishTestJobsPathInfo() {
 	log.WithField(common.LOG_TEST_SUITE, "polish-test-jobs-path-info").Debug("suite '", s.Name, "' total tests ", len(s.Tests))
-	for _, test := range s.Tests {
+	if !s.fromRender && s.inlineSnapshots == nil {
+		s.inlineSnapshots = make(map[inlineSnapshotPosition]string)
+	}
+	for idx, test := range s.Tests {
 		if test != nil {
 			test.chartRoute = s.chartRoute
 			test.definitionFile = s.definitionFile
+			test.index = idx
+			test.inlineSnapshots = s.inlineSnapshots
 
 			s.polishReleaseSettings(test)
 			s.polishCapabilitiesSettings(test)
 			s.polishKubernetesProviderSettings(test)
 			s.polishChartSettings(test)
 			s.polishSkipSettings(test)
+			s.polishUpgradeFromSettings(test)
+			s.polishFunctionsSettings(test)
+			s.polishMocksSettings(test)
+			s.polishSnapshotSettings(test)
 
 			// Make deep clone of global set
 			test.globalSet = copySet(s.Set)
+			test.globalSetValues = s.SetValues
+			test.vars = s.Vars
+			test.substitutesVars = s.Vars != nil || s.SubstituteEnv
+			test.schemaOnly = s.SchemaOnly
+			test.mutant = s.mutant
 			if len(s.Values) > 0 {
 				test.Values = append(s.Values, test.Values...)
 			}
+			if len(s.Unset) > 0 {
+				test.Unset = slices.Concat(s.Unset, test.Unset)
+			}
 			log.WithField(common.LOG_TEST_SUITE, "polish-test-jobs-path-info").Debug("test '", test.Name, "' with total values ", len(test.Values), " and ", test.Values)
 
 			if len(s.Templates) > 0 {
@@ -331,7 +372,9 @@ func (s *TestSuite) polishReleaseSettings(test *TestJob) {
 	test.Release.Name = cmp.Or(test.Release.Name, s.Release.Name)
 	test.Release.Namespace = cmp.Or(test.Release.Namespace, s.Release.Namespace)
 	test.Release.Revision = cmp.Or(test.Release.Revision, s.Release.Revision)
-	test.Release.IsUpgrade = cmp.Or(test.Release.IsUpgrade, s.Release.IsUpgrade)
+	if test.Release.IsUpgrade == nil {
+		test.Release.IsUpgrade = s.Release.IsUpgrade
+	}
 	log.WithField(common.LOG_TEST_SUITE, "polish-release-settings").Debug("test.release '", test.Release)
 }
 
@@ -352,6 +395,7 @@ func (s *TestSuite) polishCapabilitiesSettings(test *TestJob) {
 func (s *TestSuite) polishKubernetesProviderSettings(test *TestJob) {
 
 	test.KubernetesProvider.Objects = append(test.KubernetesProvider.Objects, s.KubernetesProvider.Objects...)
+	test.KubernetesProvider.Files = append(test.KubernetesProvider.Files, s.KubernetesProvider.Files...)
 
 	if len(s.KubernetesProvider.Scheme) > 0 {
 		if test.KubernetesProvider.Scheme == nil {
@@ -363,6 +407,57 @@ func (s *TestSuite) polishKubernetesProviderSettings(test *TestJob) {
 	}
 }
 
+// use the upgradeFrom settings of the testsuite when not defined in testjobs
+func (s *TestSuite) polishUpgradeFromSettings(test *TestJob) {
+	if test.UpgradeFrom == nil {
+		test.UpgradeFrom = s.UpgradeFrom
+	}
+}
+
+// use the function stubs of the testsuite when not defined in testjobs
+func (s *TestSuite) polishFunctionsSettings(test *TestJob) {
+	if test.Functions == nil {
+		test.Functions = s.Functions
+	}
+}
+
+// merge the mocks of the testsuite with the mocks of the testjobs, the testjob mocks take precedence
+func (s *TestSuite) polishMocksSettings(test *TestJob) {
+	if s.Mocks == nil {
+		return
+	}
+
+	mocks := &Mocks{
+		Templates: map[string]string{},
+		Functions: map[string][]MockFunction{},
+	}
+	for _, source := range []*Mocks{s.Mocks, test.Mocks} {
+		if source == nil {
+			continue
+		}
+		for name, template := range source.Templates {
+			mocks.Templates[name] = template
+		}
+		for name, functions := range source.Functions {
+			mocks.Functions[name] = functions
+		}
+	}
+	test.Mocks = mocks
+}
+
+// merge the snapshot redactions of the testsuite with the redactions of the testjobs
+func (s *TestSuite) polishSnapshotSettings(test *TestJob) {
+	if s.Snapshot == nil {
+		return
+	}
+
+	redaction := *s.Snapshot
+	if test.Snapshot != nil {
+		redaction = redaction.Merge(*test.Snapshot)
+	}
+	test.Snapshot = &redaction
+}
+
 // override chart settings in testjobs when defined in testsuite
 func (s *TestSuite) polishChartSettings(test *TestJob) {
 	test.Chart.Version = cmp.Or(test.Chart.Version, s.Chart.Version)
@@ -386,6 +481,7 @@ func (s *TestSuite) runV3TestJobs(
 	result := SuiteResult{Pass: false, FailFast: false, Skip: false}
 	jobResults := make([]*results.TestJobResult, len(s.Tests))
 	skipped := 0
+	testRenderDirs := make(map[string]bool)
 
 	for idx, testJob := range s.Tests {
 		// (Re)load the chart used by this suite (with logging temporarily disabled)
@@ -405,7 +501,8 @@ func (s *TestSuite) runV3TestJobs(
 			}
 		} else {
 			testJob.WithConfig(*NewTestConfig(chart, cache,
-				WithRenderPath(renderPath),
+				WithChartPath(chartPath),
+				WithRenderPath(s.testRenderPath(renderPath, testJob, testRenderDirs)),
 				WithFailFast(failFast),
 				WithPostRendererConfig(s.PostRendererConfig),
 				WithDocumentSelector(testJob.DocumentSelector),
@@ -427,6 +524,37 @@ func (s *TestSuite) runV3TestJobs(
 	return &result
 }
 
+// suiteRenderPath returns the directory of the rendered output of the suite in the rendered output of its chart,
+// `<chartRenderPath>/<suite file>/<suite>`, empty when the rendered output is not written.
+func (s *TestSuite) suiteRenderPath(chartRenderPath, chartPath string, suiteRenderDirs map[string]bool) string {
+	if chartRenderPath == "" {
+		return ""
+	}
+	suiteFile := s.definitionFile
+	absChartPath, chartErr := filepath.Abs(chartPath)
+	absSuiteFile, suiteErr := filepath.Abs(s.definitionFile)
+	if chartErr == nil && suiteErr == nil {
+		if relPath, err := filepath.Rel(absChartPath, absSuiteFile); err == nil {
+			suiteFile = relPath
+		}
+	}
+	suiteDir := filepath.Join(
+		cmp.Or(common.FileNameOf(suiteFile), "suite"),
+		cmp.Or(common.FileNameOf(s.Name), "suite"),
+	)
+	return filepath.Join(chartRenderPath, common.UniqueName(suiteDir, suiteRenderDirs))
+}
+
+// testRenderPath returns the directory of the rendered output of the test, `<renderPath>/<test>`,
+// empty when the rendered output is not written.
+func (s *TestSuite) testRenderPath(renderPath string, testJob *TestJob, testRenderDirs map[string]bool) string {
+	if renderPath == "" {
+		return ""
+	}
+	testDir := common.UniqueName(cmp.Or(common.FileNameOf(testJob.Name), "test"), testRenderDirs)
+	return filepath.Join(renderPath, testDir)
+}
+
 // VersionMeetsMinimum check if currentVersion meets the minimumVersion requirement
 func VersionMeetsMinimum(currentVersion, minimumVersion string) bool {
 	current, err := semver.NewVersion(currentVersion)
@@ -466,6 +594,14 @@ func (s *TestSuite) validateTestSuite() error {
 			log.WithField(common.LOG_TEST_SUITE, "validate-test-suite").Debugln("no asserts found", testJob)
 			return fmt.Errorf("no asserts found")
 		}
+		if s.SchemaOnly {
+			for _, assertion := range testJob.Assertions {
+				if assertion != nil && !assertion.validatesValues {
+					return fmt.Errorf("assertion type `%s` is not supported in schemaOnly test suites, "+
+						"use failedSchemaValidation or notFailedSchemaValidation", assertion.AssertType)
+				}
+			}
+		}
 	}
 
 	return nil
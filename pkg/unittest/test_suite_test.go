@@ -1,15 +1,18 @@
 package unittest_test
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/bradleyjkemp/cupaloy/v2"
 	"github.com/helm-unittest/helm-unittest/internal/common"
 	. "github.com/helm-unittest/helm-unittest/pkg/unittest"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/results"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
 	log "github.com/sirupsen/logrus"
@@ -448,7 +451,7 @@ tests:
 	testSuite := TestSuite{}
 	common.YmlUnmarshalTestHelper(suiteDoc, &testSuite, t)
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_noasserts_template_test.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_noasserts_template_test.yaml"), false, snapshot.FileLayout)
 	suiteResult := testSuite.RunV3(testV3BasicChart, cache, true, "", &results.TestSuiteResult{})
 
 	validateTestResultAndSnapshots(t, suiteResult, false, "validate empty asserts", 1, 0, 0, 0, 0)
@@ -487,7 +490,7 @@ tests:
 	testSuite := TestSuite{}
 	common.YmlUnmarshalTestHelper(suiteDoc, &testSuite, t)
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_multiple_template_test.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_multiple_template_test.yaml"), false, snapshot.FileLayout)
 	suiteResult := testSuite.RunV3(testV3BasicChart, cache, true, "", &results.TestSuiteResult{})
 
 	validateTestResultAndSnapshots(t, suiteResult, true, "validate metadata", 1, 5, 5, 0, 0)
@@ -511,7 +514,7 @@ tests:
 	testSuite := TestSuite{}
 	common.YmlUnmarshalTestHelper(suiteDoc, &testSuite, t)
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_suite_test.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_suite_test.yaml"), false, snapshot.FileLayout)
 	suiteResult := testSuite.RunV3(testV3BasicChart, cache, true, "", &results.TestSuiteResult{})
 
 	validateTestResultAndSnapshots(t, suiteResult, true, "test suite name", 1, 2, 2, 0, 0)
@@ -547,7 +550,7 @@ tests:
 	testSuite := TestSuite{}
 	common.YmlUnmarshalTestHelper(suiteDoc, &testSuite, t)
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_suite_override_test.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_suite_override_test.yaml"), false, snapshot.FileLayout)
 	suiteResult := testSuite.RunV3(testV3BasicChart, cache, true, "", &results.TestSuiteResult{})
 
 	validateTestResultAndSnapshots(t, suiteResult, true, "test suite name", 1, 1, 1, 0, 0)
@@ -570,7 +573,7 @@ tests:
 	testSuite := TestSuite{}
 	common.YmlUnmarshalTestHelper(suiteDoc, &testSuite, t)
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_failed_suite_test.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_failed_suite_test.yaml"), false, snapshot.FileLayout)
 	suiteResult := testSuite.RunV3(testV3BasicChart, cache, true, "", &results.TestSuiteResult{})
 
 	validateTestResultAndSnapshots(t, suiteResult, false, "test suite name", 1, 0, 0, 0, 0)
@@ -593,7 +596,7 @@ tests:
 	testSuite := TestSuite{}
 	common.YmlUnmarshalTestHelper(suiteDoc, &testSuite, t)
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_subfolder_test.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_subfolder_test.yaml"), false, snapshot.FileLayout)
 	suiteResult := testSuite.RunV3(testV3WithSubFolderChart, cache, true, "", &results.TestSuiteResult{})
 
 	validateTestResultAndSnapshots(t, suiteResult, true, "test suite name", 1, 2, 2, 0, 0)
@@ -615,7 +618,7 @@ tests:
 	testSuite := TestSuite{}
 	common.YmlUnmarshalTestHelper(suiteDoc, &testSuite, t)
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_subchart_test.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_subchart_test.yaml"), false, snapshot.FileLayout)
 	suiteResult := testSuite.RunV3(testV3WithSubChart, cache, true, "", &results.TestSuiteResult{})
 
 	validateTestResultAndSnapshots(t, suiteResult, true, "test suite with subchart", 1, 1, 1, 0, 0)
@@ -659,7 +662,7 @@ tests:
 	testSuite := TestSuite{}
 	common.YmlUnmarshalTestHelper(suiteDoc, &testSuite, t)
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_subchartwithtrimming_test.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_subchartwithtrimming_test.yaml"), false, snapshot.FileLayout)
 	suiteResult := testSuite.RunV3(testV3WithSubChart, cache, true, "", &results.TestSuiteResult{})
 
 	validateTestResultAndSnapshots(t, suiteResult, true, "test cert-manager rbac with trimming", 1, 0, 0, 0, 0)
@@ -689,7 +692,7 @@ tests:
 	testSuite := TestSuite{}
 	common.YmlUnmarshalTestHelper(suiteDoc, &testSuite, t)
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_subchartwithalias_test.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_subchartwithalias_test.yaml"), false, snapshot.FileLayout)
 	suiteResult := testSuite.RunV3(testV3WithSubChart, cache, true, "", &results.TestSuiteResult{})
 
 	validateTestResultAndSnapshots(t, suiteResult, true, "test suite with subchart", 2, 2, 2, 0, 0)
@@ -796,7 +799,7 @@ tests:
 	testSuite := TestSuite{}
 	common.YmlUnmarshalTestHelper(suiteDoc, &testSuite, t)
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_nameoverride_failed_suite_test.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "v3_nameoverride_failed_suite_test.yaml"), false, snapshot.FileLayout)
 	suiteResult := testSuite.RunV3(testV3BasicChart, cache, true, "", &results.TestSuiteResult{})
 
 	validateTestResultAndSnapshots(t, suiteResult, true, "test suite name too long", 1, 0, 0, 0, 0)
@@ -1559,7 +1562,7 @@ tests:
 			testSuite := suites[0]
 
 			// Run the suite
-			cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, fmt.Sprintf("skip-reason-snapshot-%s.yaml", tc.name)), false)
+			cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, fmt.Sprintf("skip-reason-snapshot-%s.yaml", tc.name)), false, snapshot.FileLayout)
 			suiteResult := testSuite.RunV3(testV3BasicChart, cache, false, "", &results.TestSuiteResult{})
 
 			// Verify skipped status
@@ -1593,7 +1596,7 @@ func TestV3RunSuiteWithSkipTests(t *testing.T) {
 		},
 	}
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "non-empty-snapshot.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "non-empty-snapshot.yaml"), false, snapshot.FileLayout)
 	cases := []struct {
 		failFast bool
 	}{
@@ -1630,7 +1633,7 @@ func TestV3RunSuiteWithSuiteLevelSkip(t *testing.T) {
 		},
 	}
 
-	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "non-empty-snapshot.yaml"), false)
+	cache, _ := snapshot.CreateSnapshotOfSuite(path.Join(tmpdir, "non-empty-snapshot.yaml"), false, snapshot.FileLayout)
 
 	cases := []struct {
 		failFast bool
@@ -1854,3 +1857,64 @@ tests:
 		})
 	}
 }
+
+func TestV3RunnerWithSchemaOnlySuite(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "basic")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS(testV3BasicChart)))
+	assert.NoError(t, os.RemoveAll(filepath.Join(chartPath, "tests")))
+	assert.NoError(t, os.MkdirAll(filepath.Join(chartPath, "tests", "values"), 0755))
+	// The template fails to render, which is not noticed by the schema only suite.
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "templates", "failing.yaml"), []byte(`{{ fail "rendered" }}`), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "values.schema.json"), []byte(`{
+  "type": "object",
+  "properties": {"replicaCount": {"type": "integer", "minimum": 1}}
+}`), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "values", "valid.yaml"), []byte("replicaCount: 3\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "values", "invalid.yaml"), []byte("replicaCount: 0\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "schema_test.yaml"), []byte(`suite: schema
+schemaOnly: true
+tests:
+  - it: should accept the valid values
+    values:
+      - values/valid.yaml
+    asserts:
+      - notFailedSchemaValidation: {}
+  - it: should reject the invalid values
+    values:
+      - values/invalid.yaml
+    asserts:
+      - failedSchemaValidation:
+          path: replicaCount
+          messagePattern: greater than or equal to 1
+  - it: should reject a string
+    set:
+      replicaCount: two
+    asserts:
+      - failedSchemaValidation:
+          path: replicaCount
+          messagePattern: Invalid type
+`), 0644))
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/*_test.yaml"},
+	}
+	assert.True(t, runner.RunV3([]string{chartPath}), buffer.String())
+	assert.Contains(t, buffer.String(), "Tests:       3 passed, 3 total")
+}
+
+func TestV3ParseSchemaOnlySuiteWithTemplateAssertion(t *testing.T) {
+	suiteFile := filepath.Join(t.TempDir(), "schema_test.yaml")
+	assert.NoError(t, os.WriteFile(suiteFile, []byte(`suite: schema
+schemaOnly: true
+tests:
+  - it: should only support the schema assertions
+    asserts:
+      - isKind:
+          of: Deployment
+`), 0644))
+
+	_, err := ParseTestSuiteFile(suiteFile, "basic", true, []string{})
+	assert.ErrorContains(t, err, "assertion type `isKind` is not supported in schemaOnly test suites")
+}
@@ -0,0 +1,2 @@
+generated: {{ now | date "2006" }}
+session: {{ uuidv4 }}
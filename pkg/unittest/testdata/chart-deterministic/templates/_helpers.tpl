@@ -0,0 +1,4 @@
+{{/* Generate a password of the requested length */}}
+{{- define "deterministic.password" -}}
+{{- randAlphaNum . | b64enc -}}
+{{- end -}}
@@ -0,0 +1,81 @@
+package unittest
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	log "github.com/sirupsen/logrus"
+
+	v3loader "helm.sh/helm/v3/pkg/chart/loader"
+)
+
+// UpgradeFromConfig defines the previous release a test job is upgraded from.
+// When values or set are defined, they replace the values and set of the test job for the previous render.
+type UpgradeFromConfig struct {
+	Chart  string                 `yaml:"chart"`
+	Values []string               `yaml:"values"`
+	Set    map[string]interface{} `yaml:"set"`
+}
+
+// previousChartPath returns the chart of the previous release, relative paths are resolved from the test suite file.
+func (t *TestJob) previousChartPath() string {
+	if t.UpgradeFrom.Chart == "" {
+		return t.configOrDefault().chartPath
+	}
+	if filepath.IsAbs(t.UpgradeFrom.Chart) {
+		return t.UpgradeFrom.Chart
+	}
+	return filepath.Join(filepath.Dir(t.definitionFile), t.UpgradeFrom.Chart)
+}
+
+// renderPrevious renders the previous release defined in upgradeFrom, using the same render path as the test job.
+func (t *TestJob) renderPrevious() (map[string][]common.K8sManifest, error) {
+	chartPath := t.previousChartPath()
+	if chartPath == "" {
+		return nil, fmt.Errorf("upgradeFrom: no chart found to upgrade from")
+	}
+
+	previousChart, err := v3loader.Load(chartPath)
+	if err != nil {
+		return nil, fmt.Errorf("upgradeFrom: %w", err)
+	}
+
+	// The previous render holds the same templates as the documents the assertions compare with
+	previous := *t
+	if t.assertsAllTemplates() {
+		previous = *t.withAllTemplates()
+	}
+	previous.UpgradeFrom = nil
+	isUpgrade := false
+	previous.Release.IsUpgrade = &isUpgrade
+	previous.Chart.Version = ""
+	previous.Chart.AppVersion = ""
+	previous.requireRenderSuccess = true
+	previous.config.targetChart = previousChart
+	previous.config.renderPath = ""
+	if len(t.UpgradeFrom.Values) > 0 {
+		previous.Values = t.UpgradeFrom.Values
+	}
+	if t.UpgradeFrom.Set != nil {
+		previous.Set = t.UpgradeFrom.Set
+	}
+
+	userValues, err := previous.getUserValues()
+	if err != nil {
+		return nil, fmt.Errorf("upgradeFrom: %w", err)
+	}
+
+	outputOfFiles, _, err := previous.renderV3Chart([]byte(userValues))
+	if err != nil {
+		return nil, fmt.Errorf("upgradeFrom: %w", err)
+	}
+
+	postRenderedManifestsOfFiles, _, err := previous.postRender(outputOfFiles)
+	if err != nil {
+		return nil, fmt.Errorf("upgradeFrom: %w", err)
+	}
+
+	log.WithField(LOG_TEST_JOB, "render-previous").Debug("previous chart ", chartPath, " rendered ", len(outputOfFiles), " files")
+	return previous.parseManifestsFromOutputOfFiles(postRenderedManifestsOfFiles)
+}
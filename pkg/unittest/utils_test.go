@@ -11,6 +11,7 @@ import (
 	"github.com/helm-unittest/helm-unittest/internal/common"
 	. "github.com/helm-unittest/helm-unittest/pkg/unittest"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -410,3 +411,286 @@ Expected pattern '.*not-in-snapshot.*' not found in snapshot:
 	assert.Contains(t, buffer.String(), "Tests:       1 failed, 0 passed, 1 total")
 	assert.Contains(t, buffer.String(), "Snapshot:    1 passed, 1 total")
 }
+
+func TestV3RunnerWith_Fixture_Chart_Upgrade(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/*_test.yaml"},
+		Strict:    true,
+	}
+	passed := runner.RunV3([]string{"testdata/chart-upgrade"})
+	assert.True(t, passed, buffer.String())
+	assert.Contains(t, buffer.String(), "Tests:       9 passed, 9 total")
+}
+
+func TestV3RunnerWith_Fixture_Chart_References(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/*_test.yaml"},
+		Strict:    true,
+	}
+	passed := runner.RunV3([]string{"testdata/chart-references"})
+	assert.True(t, passed, buffer.String())
+	assert.Contains(t, buffer.String(), "Tests:       2 passed, 2 total")
+}
+
+func TestV3RunnerWith_Fixture_Chart_Deterministic(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/*_test.yaml"},
+		Strict:    true,
+	}
+	passed := runner.RunV3([]string{"testdata/chart-deterministic"})
+	assert.True(t, passed, buffer.String())
+	assert.Contains(t, buffer.String(), "Snapshot:    1 passed, 1 total")
+	assert.Contains(t, buffer.String(), "Tests:       5 passed, 5 total")
+}
+
+func TestV3RunnerWith_Fixture_Chart_DeterministicFlag(t *testing.T) {
+	cases := []struct {
+		deterministic bool
+		expected      bool
+	}{
+		{deterministic: true, expected: true},
+		{deterministic: false, expected: false},
+	}
+
+	for _, tt := range cases {
+		t.Run(fmt.Sprintf("deterministic %t", tt.deterministic), func(t *testing.T) {
+			buffer := new(bytes.Buffer)
+			runner := TestRunner{
+				Printer:       printer.NewPrinter(buffer, nil),
+				TestFiles:     []string{"tests/flag/*_test.yaml"},
+				Deterministic: tt.deterministic,
+			}
+			passed := runner.RunV3([]string{"testdata/chart-deterministic"})
+			assert.Equal(t, tt.expected, passed, buffer.String())
+		})
+	}
+}
+
+func TestV3RunnerWith_Fixture_Chart_Mocks(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/*_test.yaml"},
+		Strict:    true,
+	}
+	passed := runner.RunV3([]string{"testdata/chart-mocks"})
+	assert.True(t, passed, buffer.String())
+	assert.Contains(t, buffer.String(), "Tests:       5 passed, 5 total")
+}
+
+func TestV3RunnerWith_Fixture_Chart_KubernetesProvider(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/*_test.yaml"},
+		Strict:    true,
+	}
+	passed := runner.RunV3([]string{"testdata/chart-k8s-provider"})
+	assert.True(t, passed, buffer.String())
+	assert.Contains(t, buffer.String(), "Tests:       4 passed, 4 total")
+}
+
+func TestV3RunnerWith_Fixture_Chart_WithSnapshot_Names(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/names/*_test.yaml"},
+		Strict:    true,
+	}
+	passed := runner.RunV3([]string{"testdata/chart-snapshot"})
+
+	assert.True(t, passed, buffer.String())
+	assert.Contains(t, buffer.String(), "Snapshot:    3 passed, 3 total")
+}
+
+func TestV3RunnerWith_Fixture_Chart_WithSnapshot_Redaction(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "chart-snapshot")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS("testdata/chart-snapshot")))
+	// The redacted and ignored values change, without changing the snapshots.
+	testFile := filepath.Join(chartPath, "tests", "redaction", "secret-snapshot_test.yaml")
+	content, err := os.ReadFile(testFile)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(testFile, []byte(strings.ReplaceAll(string(content), "generated", "rotated")), 0644))
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/redaction/*_test.yaml"},
+		Strict:    true,
+	}
+	passed := runner.RunV3([]string{chartPath})
+
+	assert.True(t, passed, buffer.String())
+	assert.Contains(t, buffer.String(), "Snapshot:    3 passed, 3 total")
+}
+
+func TestV3RunnerWith_Fixture_Chart_WithSnapshot_NamesMigrated(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "chart-snapshot")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS("testdata/chart-snapshot")))
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:        printer.NewPrinter(buffer, nil),
+		TestFiles:      []string{"tests/names/*_test.yaml"},
+		Strict:         true,
+		UpdateSnapshot: true,
+	}
+	passed := runner.RunV3([]string{chartPath})
+	assert.True(t, passed, buffer.String())
+
+	expectedSnapshot := `# suite: network-snapshot_test.yaml
+should store the snapshots by name:
+  labels: |
+    app: test-cluster
+    app.kubernetes.io/version: null
+  'labels #2': |
+    app: test-cluster
+    app.kubernetes.io/version: null
+  snapshot/templates/network.yaml NetworkPolicy/ metadata.labels: |
+    app: test-cluster
+    app.kubernetes.io/version: null
+`
+	content, err := os.ReadFile(filepath.Join(chartPath, "tests", "names", "__snapshot__", "network-snapshot_test.yaml.snap"))
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSnapshot, string(content))
+}
+
+func TestV3RunnerWith_Fixture_Chart_WithSnapshot_CI(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "chart-snapshot")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS("testdata/chart-snapshot")))
+	snapshotFile := filepath.Join(chartPath, "tests", "success", "__snapshot__", "network-snapshot_test.yaml.snap")
+	assert.NoError(t, os.Remove(snapshotFile))
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/success/*_test.yaml"},
+		Strict:    true,
+		CI:        true,
+	}
+	passed := runner.RunV3([]string{chartPath})
+
+	assert.False(t, passed, buffer.String())
+	assert.Contains(t, buffer.String(), "new snapshots are not stored in CI mode")
+	assert.Contains(t, buffer.String(), "Snapshot:    5 failed, 0 passed, 5 total")
+	assert.NoFileExists(t, snapshotFile)
+}
+
+func TestV3RunnerWith_Fixture_Chart_WithSnapshot_Prune(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "chart-snapshot")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS("testdata/chart-snapshot")))
+	snapshotDir := filepath.Join(chartPath, "tests", "success", "__snapshot__")
+	snapshotFile := filepath.Join(snapshotDir, "network-snapshot_test.yaml.snap")
+	content, err := os.ReadFile(snapshotFile)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(snapshotFile, append(content, []byte("removed test:\n  1: |\n    a: b\n")...), 0644))
+	obsoleteFile := filepath.Join(snapshotDir, "removed_test.yaml_v2.snap")
+	assert.NoError(t, os.WriteFile(obsoleteFile, []byte("# suite: removed_test.yaml\nremoved test:\n  1: |\n    a: b\n"), 0644))
+	// The snapshot file of another snapshotId of an existing suite, and a snapshot file without the recorded suite are kept.
+	ownedFile := filepath.Join(snapshotDir, "other_v2.snap")
+	assert.NoError(t, os.WriteFile(ownedFile, []byte("# suite: network-snapshot_test.yaml\nremoved test:\n  1: |\n    a: b\n"), 0644))
+	unrecordedFile := filepath.Join(snapshotDir, "unrecorded_test.yaml.snap")
+	assert.NoError(t, os.WriteFile(unrecordedFile, []byte("removed test:\n  1: |\n    a: b\n"), 0644))
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/success/*_test.yaml"},
+		Strict:    true,
+	}
+	assert.True(t, runner.RunV3([]string{chartPath}), buffer.String())
+	assert.FileExists(t, obsoleteFile)
+	stored, err := os.ReadFile(snapshotFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(stored), "removed test:")
+
+	buffer.Reset()
+	runner = TestRunner{
+		Printer:        printer.NewPrinter(buffer, nil),
+		TestFiles:      []string{"tests/success/*_test.yaml"},
+		Strict:         true,
+		PruneSnapshots: true,
+	}
+	assert.True(t, runner.RunV3([]string{chartPath}), buffer.String())
+	assert.Contains(t, buffer.String(), "1 obsolete snapshot and 1 obsolete snapshot file removed.")
+	assert.NoFileExists(t, obsoleteFile)
+	assert.FileExists(t, ownedFile)
+	assert.FileExists(t, unrecordedFile)
+	stored, err = os.ReadFile(snapshotFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "# suite: network-snapshot_test.yaml\n"+string(content), string(stored))
+}
+
+func TestV3RunnerWith_Fixture_Chart_WithSnapshot_DocumentLayout(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "chart-snapshot")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS("testdata/chart-snapshot")))
+	snapshotFile := filepath.Join(chartPath, "tests", "success", "__snapshot__", "network-snapshot_test.yaml.snap")
+	content, err := os.ReadFile(snapshotFile)
+	assert.NoError(t, err)
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:          printer.NewPrinter(buffer, nil),
+		TestFiles:        []string{"tests/success/*_test.yaml"},
+		Strict:           true,
+		SnapshotLayout:   snapshot.DocumentLayout,
+		ConvertSnapshots: true,
+	}
+	assert.True(t, runner.RunV3([]string{chartPath}), buffer.String())
+	assert.NoFileExists(t, snapshotFile)
+	documentFiles, err := filepath.Glob(filepath.Join(chartPath, "tests", "success", "__snapshot__", "network-snapshot_test.yaml", "*", "*.snap"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, documentFiles)
+
+	buffer.Reset()
+	runner = TestRunner{
+		Printer:        printer.NewPrinter(buffer, nil),
+		TestFiles:      []string{"tests/success/*_test.yaml"},
+		Strict:         true,
+		SnapshotLayout: snapshot.DocumentLayout,
+	}
+	assert.True(t, runner.RunV3([]string{chartPath}), buffer.String())
+	assert.Contains(t, buffer.String(), "Snapshot:    5 passed, 5 total")
+
+	runner = TestRunner{
+		Printer:          printer.NewPrinter(buffer, nil),
+		TestFiles:        []string{"tests/success/*_test.yaml"},
+		Strict:           true,
+		ConvertSnapshots: true,
+	}
+	assert.True(t, runner.RunV3([]string{chartPath}), buffer.String())
+	stored, err := os.ReadFile(snapshotFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "# suite: network-snapshot_test.yaml\n"+string(content), string(stored))
+	assert.NoDirExists(t, filepath.Join(chartPath, "tests", "success", "__snapshot__", "network-snapshot_test.yaml"))
+}
+
+func TestV3RunnerWith_Fixture_Chart_WithSnapshot_Diff(t *testing.T) {
+	chartPath := filepath.Join(t.TempDir(), "chart-snapshot")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS("testdata/chart-snapshot")))
+	snapshotFile := filepath.Join(chartPath, "tests", "success", "__snapshot__", "network-snapshot_test.yaml.snap")
+	content, err := os.ReadFile(snapshotFile)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(snapshotFile, []byte(strings.Replace(string(content), "app: test-cluster", "app: other-cluster", 1)), 0644))
+
+	for lineDiff, expected := range map[bool]string{
+		false: `metadata.labels.app: "other-cluster" -> "test-cluster"`,
+		true:  "-    app: other-cluster",
+	} {
+		buffer := new(bytes.Buffer)
+		runner := TestRunner{
+			Printer:   printer.NewPrinter(buffer, nil),
+			TestFiles: []string{"tests/success/*_test.yaml"},
+			Strict:    true,
+			LineDiff:  lineDiff,
+		}
+		assert.False(t, runner.RunV3([]string{chartPath}))
+		assert.Contains(t, buffer.String(), expected)
+	}
+}
@@ -25,14 +25,34 @@ type SnapshotComparer interface {
 	CompareToSnapshot(content interface{}, optFns ...func(options *snapshot.CacheOptions) error) *snapshot.CompareResult
 }
 
+// InlineSnapshotComparer provide CompareToInlineSnapshot utility to validator
+type InlineSnapshotComparer interface {
+	CompareToInlineSnapshot(expected, actual string) *snapshot.CompareResult
+}
+
 // ValidateContext the context passed to validators
 type ValidateContext struct {
 	Docs         []common.K8sManifest
 	SelectedDocs *[]common.K8sManifest
-	Negative     bool
+	// DocumentIndices the indices of the selected documents in the rendered template, shown in the fail info,
+	// nil to show the index in the selected documents
+	DocumentIndices []int
+	// AllDocs all documents rendered by the test job, required for cross-document validations
+	AllDocs []common.K8sManifest
+	// AllPreviousDocs all documents of the previous render (upgradeFrom), nil when not configured
+	AllPreviousDocs []common.K8sManifest
+	// Template the template of the documents, identifies the snapshots of the documents
+	Template string
+	Negative bool
 	SnapshotComparer
+	// SnapshotRedaction the redactions of the suite and test, applied by the snapshot validators
+	SnapshotRedaction snapshot.Redaction
+	// InlineSnapshotComparer compares and stores the inline snapshot of the assertion
+	InlineSnapshotComparer
 	RenderError error
-	FailFast    bool
+	// TemplateError the structured error of the failed render, nil when the render succeeded
+	TemplateError *TemplateError
+	FailFast      bool
 }
 
 func (c *ValidateContext) getManifests() []common.K8sManifest {
@@ -44,6 +64,14 @@ func (c *ValidateContext) getManifests() []common.K8sManifest {
 	}
 }
 
+// documentIndex returns the index of the selected document in the rendered template.
+func (c *ValidateContext) documentIndex(selectedIndex int) int {
+	if selectedIndex < 0 || selectedIndex >= len(c.DocumentIndices) {
+		return selectedIndex
+	}
+	return c.DocumentIndices[selectedIndex]
+}
+
 // Validatable all validators must implement Validate method
 type Validatable interface {
 	Validate(context *ValidateContext) (bool, []string)
@@ -128,7 +128,7 @@ func (v ContainsDocumentValidator) Validate(context *ValidateContext) (bool, []s
 
 		if manifestSuccess == context.Negative {
 			manifestSuccess = false
-			errorMessage := v.failInfo(v.Kind, idx, -1, context.Negative)
+			errorMessage := v.failInfo(v.Kind, context.documentIndex(idx), -1, context.Negative)
 			validateErrors = append(validateErrors, errorMessage...)
 
 			if context.FailFast {
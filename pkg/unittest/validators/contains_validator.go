@@ -154,7 +154,7 @@ func (v ContainsValidator) Validate(context *ValidateContext) (bool, []string) {
 	validateErrors := make([]string, 0)
 
 	for manifestIndex, manifest := range manifests {
-		manifestSuccess, manifestValidateErrors := v.validateManifest(manifest, manifestIndex, context)
+		manifestSuccess, manifestValidateErrors := v.validateManifest(manifest, context.documentIndex(manifestIndex), context)
 		validateErrors = append(validateErrors, manifestValidateErrors...)
 		validateSuccess = determineSuccess(manifestIndex, validateSuccess, manifestSuccess)
 
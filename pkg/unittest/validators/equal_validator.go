@@ -99,7 +99,7 @@ func (a EqualValidator) Validate(context *ValidateContext) (bool, []string) {
 	validateErrors := make([]string, 0)
 
 	for manifestIndex, manifest := range manifests {
-		validateManifestSuccess, validateManifestErrors := a.validateManifest(manifest, manifestIndex, context)
+		validateManifestSuccess, validateManifestErrors := a.validateManifest(manifest, context.documentIndex(manifestIndex), context)
 		validateErrors = append(validateErrors, validateManifestErrors...)
 		validateSuccess = determineSuccess(manifestIndex, validateSuccess, validateManifestSuccess)
 
@@ -37,7 +37,7 @@ func (v ExistsValidator) Validate(context *ValidateContext) (bool, []string) {
 		actual, err := valueutils.GetValueOfSetPath(manifest, v.Path)
 		if err != nil {
 			validateSuccess = false
-			errorMessage := splitInfof(errorFormat, idx, -1, err.Error())
+			errorMessage := splitInfof(errorFormat, context.documentIndex(idx), -1, err.Error())
 			validateErrors = append(validateErrors, errorMessage...)
 			if context.FailFast {
 				break
@@ -47,7 +47,7 @@ func (v ExistsValidator) Validate(context *ValidateContext) (bool, []string) {
 
 		if len(actual) > 0 == context.Negative {
 			validateSuccess = false
-			errorMessage := v.failInfo(idx, -1, context.Negative)
+			errorMessage := v.failInfo(context.documentIndex(idx), -1, context.Negative)
 			validateErrors = append(validateErrors, errorMessage...)
 			continue
 		}
@@ -0,0 +1,80 @@
+package validators
+
+import (
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// FailedSchemaValidationValidator validate whether the values violate the values.schema.json of the chart,
+// at the path and with a message matching the messagePattern
+type FailedSchemaValidationValidator struct {
+	Path           string
+	MessagePattern string `yaml:"messagePattern"`
+}
+
+func (v FailedSchemaValidationValidator) failInfo(violations []string, not bool) []string {
+	customMessage := " to fail schema validation with"
+	expected := v.MessagePattern
+	if expected == "" {
+		expected = "any message"
+	}
+	actual := "no schema violation"
+	if len(violations) > 0 {
+		actual = strings.Join(violations, "\n")
+	}
+
+	log.WithField("validator", "failed_schema_validation").Debugln("expected content:", expected)
+	log.WithField("validator", "failed_schema_validation").Debugln("actual content:", actual)
+
+	replacements := make([]string, 0, 3)
+	if v.Path != "" {
+		replacements = append(replacements, v.Path)
+	}
+	replacements = append(replacements, expected)
+	if !not {
+		replacements = append(replacements, actual)
+	}
+	return splitInfof(
+		setFailFormat(not, v.Path != "", !not, false, customMessage),
+		-1,
+		-1,
+		replacements...,
+	)
+}
+
+// matches returns true when the violation `path: message` is at the path and matches the pattern.
+func (v FailedSchemaValidationValidator) matches(violation string, pattern *regexp.Regexp) bool {
+	path, message, _ := strings.Cut(violation, ": ")
+	return (v.Path == "" || v.Path == path) && (pattern == nil || pattern.MatchString(message))
+}
+
+// Validate implement Validatable
+func (v FailedSchemaValidationValidator) Validate(context *ValidateContext) (bool, []string) {
+	var pattern *regexp.Regexp
+	if v.MessagePattern != "" {
+		var err error
+		if pattern, err = regexp.Compile(v.MessagePattern); err != nil {
+			return false, splitInfof(errorFormat, -1, -1, err.Error())
+		}
+	}
+
+	var violations []string
+	if context.TemplateError != nil {
+		violations = context.TemplateError.SchemaErrors
+	}
+
+	matched := false
+	for _, violation := range violations {
+		if v.matches(violation, pattern) {
+			matched = true
+			break
+		}
+	}
+
+	if matched == context.Negative {
+		return false, v.failInfo(violations, context.Negative)
+	}
+	return true, []string{}
+}
@@ -0,0 +1,87 @@
+package validators_test
+
+import (
+	"testing"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+var schemaTemplateErrorToTest = &TemplateError{
+	SchemaErrors: []string{
+		"(root): name is required",
+		"replicaCount: Invalid type. Expected: integer, given: string",
+	},
+}
+
+func TestFailedSchemaValidationValidatorWhenOk(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator FailedSchemaValidationValidator
+	}{
+		{name: "any violation", validator: FailedSchemaValidationValidator{}},
+		{name: "path", validator: FailedSchemaValidationValidator{Path: "replicaCount"}},
+		{name: "message pattern", validator: FailedSchemaValidationValidator{MessagePattern: "name is required"}},
+		{name: "path and message pattern", validator: FailedSchemaValidationValidator{Path: "replicaCount", MessagePattern: "Expected: integer"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pass, diff := tt.validator.Validate(&ValidateContext{TemplateError: schemaTemplateErrorToTest})
+			assert.True(t, pass)
+			assert.Equal(t, []string{}, diff)
+		})
+	}
+}
+
+func TestFailedSchemaValidationValidatorWhenFail(t *testing.T) {
+	validator := FailedSchemaValidationValidator{Path: "replicaCount", MessagePattern: "required"}
+	pass, diff := validator.Validate(&ValidateContext{TemplateError: schemaTemplateErrorToTest})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Path:\treplicaCount",
+		"Expected to fail schema validation with:",
+		"\trequired",
+		"Actual:",
+		"\t(root): name is required",
+		"\treplicaCount: Invalid type. Expected: integer, given: string",
+	}, diff)
+}
+
+func TestFailedSchemaValidationValidatorWhenNoViolation(t *testing.T) {
+	validator := FailedSchemaValidationValidator{}
+	pass, diff := validator.Validate(&ValidateContext{})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Expected to fail schema validation with:",
+		"\tany message",
+		"Actual:",
+		"\tno schema violation",
+	}, diff)
+}
+
+func TestFailedSchemaValidationValidatorWhenNegative(t *testing.T) {
+	validator := FailedSchemaValidationValidator{Path: "image.tag"}
+	pass, diff := validator.Validate(&ValidateContext{TemplateError: schemaTemplateErrorToTest, Negative: true})
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+
+	validator = FailedSchemaValidationValidator{Path: "replicaCount"}
+	pass, diff = validator.Validate(&ValidateContext{TemplateError: schemaTemplateErrorToTest, Negative: true})
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Path:\treplicaCount",
+		"Expected NOT to fail schema validation with:",
+		"\tany message",
+	}, diff)
+}
+
+func TestFailedSchemaValidationValidatorWhenInvalidPattern(t *testing.T) {
+	validator := FailedSchemaValidationValidator{MessagePattern: "(unclosed"}
+	pass, diff := validator.Validate(&ValidateContext{TemplateError: schemaTemplateErrorToTest})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{"Error:", "\terror parsing regexp: missing closing ): `(unclosed`"}, diff)
+}
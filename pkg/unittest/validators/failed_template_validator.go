@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
@@ -15,10 +18,27 @@ const metaCharactersPattern = `.*[\\.+*?()|[\]{}^$].*`
 
 var metaRegex = regexp.MustCompile(metaCharactersPattern)
 
-// FailedTemplateValidator validate whether the errorMessage equal to errorMessage
+// FailedTemplateValidator validate whether the errorMessage equal to errorMessage, and the template,
+// line and schema errors of the structured error
 type FailedTemplateValidator struct {
 	ErrorMessage string
 	ErrorPattern string
+	// Template the template of the error, the path can be relative to the chart
+	Template string
+	// Line the line of the error in the template
+	Line int
+	// SchemaErrors the JSON schema violations, which the violations of the values have to contain
+	SchemaErrors []string
+}
+
+// isEmpty returns true when nothing is asserted, besides the failure.
+func (a FailedTemplateValidator) isEmpty() bool {
+	return a.ErrorMessage == "" && a.ErrorPattern == "" && !a.assertsTemplateError()
+}
+
+// assertsTemplateError returns true when the fields of the structured error are asserted.
+func (a FailedTemplateValidator) assertsTemplateError() bool {
+	return a.Template != "" || a.Line != 0 || len(a.SchemaErrors) > 0
 }
 
 func (a FailedTemplateValidator) failInfo(actual interface{}, manifestIndex, actualIndex int, not bool) []string {
@@ -59,16 +79,16 @@ func (a FailedTemplateValidator) validateManifests(manifests []common.K8sManifes
 		var validateSingleErrors []string
 		actual := manifest[common.RAW]
 
-		if a == (FailedTemplateValidator{}) && !context.Negative {
+		if a.isEmpty() && !context.Negative {
 			// If the validator is empty and the context is not negative,
 			// continue to the next iteration without throwing an error.
 			continue
 		}
 
 		if a.ErrorPattern != "" {
-			currentSuccess, validateSingleErrors = a.validateErrorPattern(actual, idx, -1, context)
+			currentSuccess, validateSingleErrors = a.validateErrorPattern(actual, context.documentIndex(idx), -1, context)
 		} else if a.ErrorMessage != "" {
-			currentSuccess, validateSingleErrors = a.validateErrorMessage(actual, idx, -1, context)
+			currentSuccess, validateSingleErrors = a.validateErrorMessage(actual, context.documentIndex(idx), -1, context)
 		} else {
 			currentSuccess = true
 		}
@@ -164,5 +184,51 @@ func (a FailedTemplateValidator) Validate(context *ValidateContext) (bool, []str
 		validateErrors = append(validateErrors, errorsToAppend...)
 	}
 
+	if a.assertsTemplateError() {
+		templateErrorSuccess, errorsToAppend := a.validateTemplateError(context)
+		validateSuccess = validateSuccess && templateErrorSuccess
+		validateErrors = append(validateErrors, errorsToAppend...)
+	}
+
+	return validateSuccess, validateErrors
+}
+
+// validateTemplateError validates the template, line and schema errors of the structured error of the render.
+func (a FailedTemplateValidator) validateTemplateError(context *ValidateContext) (bool, []string) {
+	templateError := context.TemplateError
+	if templateError == nil {
+		templateError = &TemplateError{}
+	}
+
+	validateSuccess := true
+	validateErrors := make([]string, 0)
+	validate := func(matched bool, customMessage, expected, actual string) {
+		if matched == context.Negative {
+			validateSuccess = false
+			validateErrors = append(validateErrors, a.templateErrorFailInfo(customMessage, expected, actual, context.Negative)...)
+		}
+	}
+
+	if a.Template != "" {
+		matched := templateError.Template == a.Template || strings.HasSuffix(templateError.Template, "/"+a.Template)
+		validate(matched, " template of the error to be", a.Template, templateError.Template)
+	}
+	if a.Line != 0 {
+		validate(templateError.Line == a.Line, " line of the error to be", strconv.Itoa(a.Line), strconv.Itoa(templateError.Line))
+	}
+	for _, schemaError := range a.SchemaErrors {
+		validate(slices.Contains(templateError.SchemaErrors, schemaError), " schema errors to contain",
+			schemaError, strings.Join(templateError.SchemaErrors, "\n"))
+	}
 	return validateSuccess, validateErrors
 }
+
+func (a FailedTemplateValidator) templateErrorFailInfo(customMessage, expected, actual string, not bool) []string {
+	log.WithField("validator", "failed_template").Debugln("expected content:", expected)
+	log.WithField("validator", "failed_template").Debugln("actual content:", actual)
+
+	if not {
+		return splitInfof(setFailFormat(not, false, false, false, customMessage), -1, -1, expected)
+	}
+	return splitInfof(setFailFormat(not, false, true, false, customMessage), -1, -1, expected, actual)
+}
@@ -566,3 +566,75 @@ func TestFailedTemplateValidator_HandleMetaCharacters_InvalidUtfSequence(t *test
 		assert.False(t, pass)
 	}
 }
+
+func TestFailedTemplateValidatorWithTemplateError(t *testing.T) {
+	templateError := &TemplateError{
+		Template:     "basic/templates/deployment.yaml",
+		Line:         12,
+		Message:      "image is required",
+		SchemaErrors: []string{"replicas: Invalid type. Expected: integer, given: string"},
+	}
+	tests := []struct {
+		name      string
+		validator FailedTemplateValidator
+		negative  bool
+		pass      bool
+		diff      []string
+	}{
+		{
+			name:      "template and line",
+			validator: FailedTemplateValidator{Template: "templates/deployment.yaml", Line: 12},
+			pass:      true,
+			diff:      []string{},
+		},
+		{
+			name:      "schema errors",
+			validator: FailedTemplateValidator{SchemaErrors: []string{"replicas: Invalid type. Expected: integer, given: string"}},
+			pass:      true,
+			diff:      []string{},
+		},
+		{
+			name:      "other template",
+			validator: FailedTemplateValidator{Template: "templates/service.yaml"},
+			diff: []string{
+				"Expected template of the error to be:",
+				"\ttemplates/service.yaml",
+				"Actual:",
+				"\tbasic/templates/deployment.yaml",
+			},
+		},
+		{
+			name:      "other line",
+			validator: FailedTemplateValidator{Line: 3},
+			diff: []string{
+				"Expected line of the error to be:",
+				"\t3",
+				"Actual:",
+				"\t12",
+			},
+		},
+		{
+			name:      "not the template",
+			validator: FailedTemplateValidator{Template: "templates/deployment.yaml"},
+			negative:  true,
+			diff: []string{
+				"Expected NOT template of the error to be:",
+				"\ttemplates/deployment.yaml",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pass, diff := tt.validator.Validate(&ValidateContext{
+				Docs:          []common.K8sManifest{},
+				RenderError:   errors.New(templateError.Message),
+				TemplateError: templateError,
+				Negative:      tt.negative,
+			})
+
+			assert.Equal(t, tt.pass, pass)
+			assert.Equal(t, tt.diff, diff)
+		})
+	}
+}
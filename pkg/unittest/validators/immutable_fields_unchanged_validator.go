@@ -0,0 +1,162 @@
+package validators
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
+)
+
+const noPreviousRenderMessage = "no previous render found, define 'upgradeFrom' in the test suite or test job"
+
+// immutableFields the fields per kind which are rejected by the api-server when changed on upgrade.
+var immutableFields = map[string][]string{
+	"Deployment":            {"spec.selector"},
+	"ReplicaSet":            {"spec.selector"},
+	"DaemonSet":             {"spec.selector"},
+	"StatefulSet":           {"spec.selector", "spec.serviceName", "spec.podManagementPolicy", "spec.volumeClaimTemplates"},
+	"Job":                   {"spec.selector", "spec.template", "spec.completionMode"},
+	"Service":               {"spec.clusterIP", "spec.clusterIPs"},
+	"PersistentVolumeClaim": {"spec.storageClassName", "spec.accessModes", "spec.volumeMode", "spec.volumeName", "spec.selector"},
+	"Secret":                {"type"},
+}
+
+// immutableDataFields the fields of ConfigMaps and Secrets which can not change when marked immutable.
+var immutableDataFields = []string{"immutable", "data", "binaryData", "stringData"}
+
+// ImmutableFieldsUnchangedValidator validate the immutable fields of the manifest are unchanged
+// compared to the previous render of the test job.
+type ImmutableFieldsUnchangedValidator struct {
+	Fields map[string][]string // optional, additional immutable fields per kind
+}
+
+func (v ImmutableFieldsUnchangedValidator) failInfo(identity, actual string, manifestIndex int, not bool) []string {
+	customMessage := " immutable fields to be unchanged"
+
+	log.WithField("validator", "immutable_fields_unchanged").Debugln("expected content:", identity)
+	log.WithField("validator", "immutable_fields_unchanged").Debugln("actual content:", actual)
+
+	if not {
+		return splitInfof(
+			setFailFormat(not, false, false, false, customMessage),
+			manifestIndex,
+			-1,
+			identity,
+		)
+	}
+	return splitInfof(
+		setFailFormat(not, false, true, false, customMessage),
+		manifestIndex,
+		-1,
+		identity,
+		actual,
+	)
+}
+
+// fieldsOf returns the immutable fields of the previous manifest.
+func (v ImmutableFieldsUnchangedValidator) fieldsOf(previous common.K8sManifest) []string {
+	kind, _ := previous["kind"].(string)
+	fields := append([]string{}, immutableFields[kind]...)
+	fields = append(fields, v.Fields[kind]...)
+
+	if immutable, _ := previous["immutable"].(bool); immutable && (kind == "ConfigMap" || kind == "Secret") {
+		fields = append(fields, immutableDataFields...)
+	}
+	return fields
+}
+
+// changedFields returns the diff of every immutable field which changed.
+func (v ImmutableFieldsUnchangedValidator) changedFields(manifest, previous common.K8sManifest) ([]string, error) {
+	changed := make([]string, 0)
+	for _, field := range v.fieldsOf(previous) {
+		previousValue, err := valueutils.GetValueOfSetPath(previous, field)
+		if err != nil {
+			return nil, err
+		}
+		currentValue, err := valueutils.GetValueOfSetPath(manifest, field)
+		if err != nil {
+			return nil, err
+		}
+		if reflect.DeepEqual(previousValue, currentValue) {
+			continue
+		}
+		changed = append(changed, fmt.Sprintf("%s changed:\n%s", field, diff(
+			common.TrustedMarshalYAML(singleValue(previousValue)),
+			common.TrustedMarshalYAML(singleValue(currentValue)),
+		)))
+	}
+	return changed, nil
+}
+
+// singleValue unwraps the value of a path without wildcards, to keep the diff readable.
+func singleValue(values []interface{}) interface{} {
+	switch len(values) {
+	case 0:
+		return nil
+	case 1:
+		return values[0]
+	}
+	return values
+}
+
+// Validate implement Validatable
+func (v ImmutableFieldsUnchangedValidator) Validate(context *ValidateContext) (bool, []string) {
+	if context.AllPreviousDocs == nil {
+		return false, splitInfof(errorFormat, -1, -1, noPreviousRenderMessage)
+	}
+
+	manifests := context.getManifests()
+
+	validateSuccess := false
+	validateErrors := make([]string, 0)
+
+	for manifestIndex, manifest := range manifests {
+		var changed []string
+		if previous, found := findManifest(manifest, context.AllPreviousDocs); found {
+			var err error
+			changed, err = v.changedFields(manifest, previous)
+			if err != nil {
+				validateErrors = append(validateErrors, splitInfof(errorFormat, context.documentIndex(manifestIndex), -1, err.Error())...)
+				validateSuccess = false
+				continue
+			}
+		}
+
+		if (len(changed) == 0) == context.Negative {
+			validateSuccess = false
+			errorMessage := v.failInfo(manifestIdentity(manifest), strings.Join(changed, "\n"), context.documentIndex(manifestIndex), context.Negative)
+			validateErrors = append(validateErrors, errorMessage...)
+			if context.FailFast {
+				break
+			}
+			continue
+		}
+
+		validateSuccess = determineSuccess(manifestIndex, validateSuccess, true)
+	}
+
+	if len(manifests) == 0 && !context.Negative {
+		errorMessage := v.failInfo("", "no manifest found", -1, context.Negative)
+		validateErrors = append(validateErrors, errorMessage...)
+	} else if len(manifests) == 0 && context.Negative {
+		validateSuccess = true
+	}
+
+	return validateSuccess, validateErrors
+}
+
+// findManifest finds the manifest with the same kind, name and namespace.
+// The apiVersion is ignored, as the same resource can be served by different apiVersions.
+func findManifest(manifest common.K8sManifest, candidates []common.K8sManifest) (common.K8sManifest, bool) {
+	for _, candidate := range candidates {
+		if manifestIdentity(candidate) == manifestIdentity(manifest) &&
+			manifestNamespace(candidate) == manifestNamespace(manifest) {
+			return candidate, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,164 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+var docToTestImmutablePrevious = `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+spec:
+  serviceName: db
+  replicas: 1
+  selector:
+    matchLabels:
+      app: db
+`
+
+var docToTestImmutableChanged = `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+spec:
+  serviceName: db-headless
+  replicas: 3
+  selector:
+    matchLabels:
+      app: db
+`
+
+var docToTestImmutableScaled = `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+spec:
+  serviceName: db
+  replicas: 3
+  selector:
+    matchLabels:
+      app: db
+`
+
+func TestImmutableFieldsUnchangedValidatorWhenOk(t *testing.T) {
+	previous := makeManifest(docToTestImmutablePrevious)
+	manifest := makeManifest(docToTestImmutableScaled)
+
+	validator := ImmutableFieldsUnchangedValidator{}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:            []common.K8sManifest{manifest},
+		AllPreviousDocs: []common.K8sManifest{previous},
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestImmutableFieldsUnchangedValidatorWhenNewResource(t *testing.T) {
+	validator := ImmutableFieldsUnchangedValidator{}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:            []common.K8sManifest{makeManifest(docToTestImmutableChanged)},
+		AllPreviousDocs: []common.K8sManifest{},
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestImmutableFieldsUnchangedValidatorWhenFail(t *testing.T) {
+	validator := ImmutableFieldsUnchangedValidator{}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:            []common.K8sManifest{makeManifest(docToTestImmutableChanged)},
+		AllPreviousDocs: []common.K8sManifest{makeManifest(docToTestImmutablePrevious)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Expected immutable fields to be unchanged:",
+		"\tStatefulSet/db",
+		"Actual:",
+		"\tspec.serviceName changed:",
+		"\t--- Expected",
+		"\t+++ Actual",
+		"\t@@ -1,2 +1,2 @@",
+		"\t-db",
+		"\t+db-headless",
+	}, diff)
+}
+
+func TestImmutableFieldsUnchangedValidatorWhenNegativeAndOk(t *testing.T) {
+	validator := ImmutableFieldsUnchangedValidator{}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:            []common.K8sManifest{makeManifest(docToTestImmutableChanged)},
+		AllPreviousDocs: []common.K8sManifest{makeManifest(docToTestImmutablePrevious)},
+		Negative:        true,
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestImmutableFieldsUnchangedValidatorWithImmutableConfigMapWhenFail(t *testing.T) {
+	previous := makeManifest(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+immutable: true
+data:
+  key: a
+`)
+	manifest := makeManifest(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+immutable: true
+data:
+  key: b
+`)
+
+	validator := ImmutableFieldsUnchangedValidator{}
+	pass, _ := validator.Validate(&ValidateContext{
+		Docs:            []common.K8sManifest{manifest},
+		AllPreviousDocs: []common.K8sManifest{previous},
+	})
+
+	assert.False(t, pass)
+}
+
+func TestImmutableFieldsUnchangedValidatorWithCustomFieldsWhenFail(t *testing.T) {
+	validator := ImmutableFieldsUnchangedValidator{
+		Fields: map[string][]string{"StatefulSet": {"spec.replicas"}},
+	}
+	previous := makeManifest(docToTestImmutablePrevious)
+	manifest := makeManifest(docToTestImmutableScaled)
+
+	pass, _ := validator.Validate(&ValidateContext{
+		Docs:            []common.K8sManifest{manifest},
+		AllPreviousDocs: []common.K8sManifest{previous},
+	})
+
+	assert.False(t, pass)
+}
+
+func TestImmutableFieldsUnchangedValidatorWithoutPreviousRender(t *testing.T) {
+	validator := ImmutableFieldsUnchangedValidator{}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(docToTestImmutablePrevious)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Error:",
+		"\tno previous render found, define 'upgradeFrom' in the test suite or test job",
+	}, diff)
+}
@@ -0,0 +1,86 @@
+package validators
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/valueutils"
+	log "github.com/sirupsen/logrus"
+)
+
+// inlineSnapshotSeparator separates the values of multiple documents in an inline snapshot
+const inlineSnapshotSeparator = "---\n"
+
+// MatchInlineSnapshotValidator validate value of Path the same as the inline snapshot in the test suite file
+type MatchInlineSnapshotValidator struct {
+	Path string
+	// Content the inline snapshot, written to the test suite file when missing or updating
+	Content string
+	// Ignore the paths of the document removed before comparing
+	Ignore []string
+	// Redact the paths of the document replaced with a placeholder before comparing
+	Redact []string
+}
+
+func (v MatchInlineSnapshotValidator) failInfo(compared *snapshot.CompareResult, not bool) []string {
+	log.WithField("validator", "inline_snapshot").Debugln("expected content:", compared.CachedSnapshot)
+	log.WithField("validator", "inline_snapshot").Debugln("actual content:", compared.NewSnapshot)
+
+	customMessage := " to match inline snapshot"
+	var infoToShow string
+	switch {
+	case compared.Msg != "":
+		customMessage = compared.Msg
+		infoToShow = compared.NewSnapshot
+	case not:
+		infoToShow = compared.CachedSnapshot
+	case compared.Diff != "":
+		infoToShow = compared.Diff
+	default:
+		infoToShow = diff(compared.CachedSnapshot, compared.NewSnapshot)
+	}
+	return splitInfof(
+		setFailFormat(not, true, false, false, customMessage),
+		-1,
+		-1,
+		v.Path,
+		infoToShow,
+	)
+}
+
+// Validate implement Validatable
+func (v MatchInlineSnapshotValidator) Validate(context *ValidateContext) (bool, []string) {
+	manifests := context.getManifests()
+	redaction := context.SnapshotRedaction.Merge(snapshot.Redaction{Ignore: v.Ignore, Redact: v.Redact})
+
+	// The values of all documents are combined in one inline snapshot.
+	values := make([]string, 0, len(manifests))
+	for manifestIndex, manifest := range manifests {
+		redacted, err := redaction.Apply(manifest)
+		if err != nil {
+			return false, splitInfof(errorFormat, context.documentIndex(manifestIndex), -1, err.Error())
+		}
+		actual, err := valueutils.GetValueOfSetPath(redacted, v.Path)
+		if err != nil {
+			return false, splitInfof(errorFormat, context.documentIndex(manifestIndex), -1, err.Error())
+		}
+		for _, singleActual := range actual {
+			values = append(values, common.TrustedMarshalYAML(singleActual))
+		}
+	}
+
+	if len(values) == 0 && !context.Negative {
+		return false, splitInfof(errorFormat, -1, -1, fmt.Sprintf("unknown path %s", v.Path))
+	}
+	if context.InlineSnapshotComparer == nil {
+		return false, splitInfof(errorFormat, -1, -1, "inline snapshots are not supported in this context")
+	}
+
+	result := context.CompareToInlineSnapshot(v.Content, strings.Join(values, inlineSnapshotSeparator))
+	if result.Passed == context.Negative {
+		return false, v.failInfo(result, context.Negative)
+	}
+	return true, []string{}
+}
@@ -0,0 +1,118 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+type inlineComparer struct {
+	actual []string
+	result snapshot.CompareResult
+}
+
+func (c *inlineComparer) CompareToInlineSnapshot(expected, actual string) *snapshot.CompareResult {
+	c.actual = append(c.actual, actual)
+	result := c.result
+	result.CachedSnapshot = expected
+	result.NewSnapshot = actual
+	return &result
+}
+
+func TestInlineSnapshotValidatorWhenOk(t *testing.T) {
+	comparer := &inlineComparer{result: snapshot.CompareResult{Passed: true}}
+	validator := MatchInlineSnapshotValidator{Path: "a", Content: "b\n"}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:                   []common.K8sManifest{makeManifest("a: b"), makeManifest("a:\n  c: d")},
+		InlineSnapshotComparer: comparer,
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+	assert.Equal(t, []string{"b\n---\nc: d\n"}, comparer.actual)
+}
+
+func TestInlineSnapshotValidatorWhenFail(t *testing.T) {
+	comparer := &inlineComparer{}
+	validator := MatchInlineSnapshotValidator{Path: "a", Content: "x\n"}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:                   []common.K8sManifest{makeManifest("a: b")},
+		InlineSnapshotComparer: comparer,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Path:\ta",
+		"Expected to match inline snapshot:",
+		"\t--- Expected",
+		"\t+++ Actual",
+		"\t@@ -1,2 +1,2 @@",
+		"\t-x",
+		"\t+b",
+	}, diff)
+}
+
+func TestInlineSnapshotValidatorWhenNegativeAndFail(t *testing.T) {
+	comparer := &inlineComparer{result: snapshot.CompareResult{Passed: true}}
+	validator := MatchInlineSnapshotValidator{Path: "a", Content: "b\n"}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Negative:               true,
+		Docs:                   []common.K8sManifest{makeManifest("a: b")},
+		InlineSnapshotComparer: comparer,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Path:\ta",
+		"Expected NOT to match inline snapshot:",
+		"\tb",
+	}, diff)
+}
+
+func TestInlineSnapshotValidatorWhenMessage(t *testing.T) {
+	comparer := &inlineComparer{result: snapshot.CompareResult{Msg: " inline snapshot to be stored, new snapshots are not stored in CI mode"}}
+	validator := MatchInlineSnapshotValidator{Path: "a"}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:                   []common.K8sManifest{makeManifest("a: b")},
+		InlineSnapshotComparer: comparer,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Path:\ta",
+		"Expected inline snapshot to be stored, new snapshots are not stored in CI mode:",
+		"\tb",
+	}, diff)
+}
+
+func TestInlineSnapshotValidatorWhenUnknownPath(t *testing.T) {
+	validator := MatchInlineSnapshotValidator{Path: "x"}
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:                   []common.K8sManifest{makeManifest("a: b")},
+		InlineSnapshotComparer: &inlineComparer{},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{"Error:", "\tunknown path x"}, diff)
+}
+
+func TestInlineSnapshotValidatorWhenRedacted(t *testing.T) {
+	comparer := &inlineComparer{result: snapshot.CompareResult{Passed: true}}
+	validator := MatchInlineSnapshotValidator{Path: "data", Ignore: []string{`data.tls\.crt`}, Redact: []string{`data.tls\.key`}}
+
+	pass, _ := validator.Validate(&ValidateContext{
+		Docs:                   []common.K8sManifest{makeManifest("data:\n  tls.crt: cert\n  tls.key: key\n")},
+		InlineSnapshotComparer: comparer,
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{"tls.key: " + snapshot.RedactedValue + "\n"}, comparer.actual)
+}
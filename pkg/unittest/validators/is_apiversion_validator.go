@@ -45,7 +45,7 @@ func (v IsAPIVersionValidator) Validate(context *ValidateContext) (bool, []strin
 	for manifestIndex, manifest := range manifests {
 		if kind, ok := manifest["apiVersion"].(string); (ok && kind == v.Of) == context.Negative {
 			validateSuccess = false
-			errorMessage := v.failInfo(manifest["apiVersion"], manifestIndex, -1, context.Negative)
+			errorMessage := v.failInfo(manifest["apiVersion"], context.documentIndex(manifestIndex), -1, context.Negative)
 			validateErrors = append(validateErrors, errorMessage...)
 			if context.FailFast {
 				break
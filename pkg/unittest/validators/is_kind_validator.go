@@ -44,7 +44,7 @@ func (v IsKindValidator) Validate(context *ValidateContext) (bool, []string) {
 	for manifestIndex, manifest := range manifests {
 		if kind, ok := manifest["kind"].(string); (ok && kind == v.Of) == context.Negative {
 			validateSuccess = false
-			errorMessage := v.failInfo(manifest["kind"], manifestIndex, -1, context.Negative)
+			errorMessage := v.failInfo(manifest["kind"], context.documentIndex(manifestIndex), -1, context.Negative)
 			validateErrors = append(validateErrors, errorMessage...)
 			if context.FailFast {
 				break
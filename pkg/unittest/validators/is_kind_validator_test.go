@@ -57,6 +57,23 @@ func TestIsKindValidatorWhenFail(t *testing.T) {
 	}, diff)
 }
 
+func TestIsKindValidatorWhenFailShowsTheDocumentIndexInTheTemplate(t *testing.T) {
+	v := IsKindValidator{"Service"}
+	pass, diff := v.Validate(&ValidateContext{
+		Docs:            []common.K8sManifest{makeManifest("kind: Pod"), makeManifest("kind: Service")},
+		DocumentIndices: []int{3, 5},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:	3",
+		"Expected to be kind:",
+		"	Service",
+		"Actual:",
+		"	Pod",
+	}, diff)
+}
+
 func TestIsKindValidatorWhenNegativeAndFail(t *testing.T) {
 	doc := "kind: Pod"
 	manifest := makeManifest(doc)
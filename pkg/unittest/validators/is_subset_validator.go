@@ -88,7 +88,7 @@ func (v IsSubsetValidator) Validate(context *ValidateContext) (bool, []string) {
 	validateErrors := make([]string, 0)
 
 	for idx, manifest := range manifests {
-		manifestValidateSuccess, manifestValidateErrors := v.validateManifest(manifest, idx, context)
+		manifestValidateSuccess, manifestValidateErrors := v.validateManifest(manifest, context.documentIndex(idx), context)
 		validateErrors = append(validateErrors, manifestValidateErrors...)
 		validateSuccess = determineSuccess(idx, validateSuccess, manifestValidateSuccess)
 
@@ -75,7 +75,7 @@ func (t IsTypeValidator) Validate(context *ValidateContext) (bool, []string) {
 	validateErrors := make([]string, 0)
 
 	for idx, manifest := range manifests {
-		manifestSuccess, manifestErrors := t.validateManifest(manifest, idx, context)
+		manifestSuccess, manifestErrors := t.validateManifest(manifest, context.documentIndex(idx), context)
 		validateErrors = append(validateErrors, manifestErrors...)
 		validateSuccess = determineSuccess(idx, validateSuccess, manifestSuccess)
 
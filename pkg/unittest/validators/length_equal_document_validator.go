@@ -152,9 +152,9 @@ func (v LengthEqualDocumentsValidator) Validate(context *ValidateContext) (bool,
 		currentSuccess := false
 		var validateManifestErrors []string
 		if singleMode {
-			currentSuccess, validateManifestErrors = v.validateSingleMode(manifest, manifestIndex, context)
+			currentSuccess, validateManifestErrors = v.validateSingleMode(manifest, context.documentIndex(manifestIndex), context)
 		} else {
-			currentSuccess, validateManifestErrors = v.validateMultipleMode(manifest, manifestIndex, context)
+			currentSuccess, validateManifestErrors = v.validateMultipleMode(manifest, context.documentIndex(manifestIndex), context)
 		}
 
 		validateErrors = append(validateErrors, validateManifestErrors...)
@@ -100,7 +100,7 @@ func (v MatchRegexValidator) Validate(context *ValidateContext) (bool, []string)
 	validateErrors := make([]string, 0)
 
 	for manifestIndex, manifest := range manifests {
-		currentSuccess, validateSingleErrors := v.validateManifest(manifest, manifestIndex, context)
+		currentSuccess, validateSingleErrors := v.validateManifest(manifest, context.documentIndex(manifestIndex), context)
 
 		validateErrors = append(validateErrors, validateSingleErrors...)
 		validateSuccess = determineSuccess(manifestIndex, validateSuccess, currentSuccess)
@@ -0,0 +1,76 @@
+package validators
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// NoResourceRemovedValidator validate all resources of the previous render of the test job are still rendered,
+// in any template. Resources are matched by kind, namespace and name.
+type NoResourceRemovedValidator struct{}
+
+func (v NoResourceRemovedValidator) failInfo(expected, actual string, not bool) []string {
+	customMessage := " to keep resources"
+
+	log.WithField("validator", "no_resource_removed").Debugln("expected content:", expected)
+	log.WithField("validator", "no_resource_removed").Debugln("actual content:", actual)
+
+	if not {
+		return splitInfof(
+			setFailFormat(not, false, false, false, customMessage),
+			-1,
+			-1,
+			expected,
+		)
+	}
+	return splitInfof(
+		setFailFormat(not, false, true, false, customMessage),
+		-1,
+		-1,
+		expected,
+		actual,
+	)
+}
+
+// Validate implement Validatable
+func (v NoResourceRemovedValidator) Validate(context *ValidateContext) (bool, []string) {
+	if context.AllPreviousDocs == nil {
+		return false, splitInfof(errorFormat, -1, -1, noPreviousRenderMessage)
+	}
+
+	current := context.AllDocs
+	if current == nil {
+		current = context.Docs
+	}
+
+	expected := make([]string, 0, len(context.AllPreviousDocs))
+	removed := make([]string, 0)
+	for _, previous := range context.AllPreviousDocs {
+		if _, ok := previous["kind"]; !ok {
+			continue
+		}
+		expected = append(expected, resourceKey(previous))
+		if _, found := findManifest(previous, current); !found {
+			removed = append(removed, resourceKey(previous)+" removed")
+		}
+	}
+
+	if (len(removed) == 0) == context.Negative {
+		return false, v.failInfo(strings.Join(expected, "\n"), strings.Join(removed, "\n"), context.Negative)
+	}
+	return true, []string{}
+}
+
+// resourceKey returns the kind, namespace and name of the manifest, the namespace is omitted when not set.
+func resourceKey(manifest common.K8sManifest) string {
+	kind, _ := manifest["kind"].(string)
+	name, _ := common.NestedString(manifest, "metadata", "name")
+	if namespace := manifestNamespace(manifest); namespace != "" {
+		return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+	}
+	return fmt.Sprintf("%s/%s", kind, name)
+}
@@ -0,0 +1,119 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+var docToTestResourceService = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+`
+
+var docToTestResourceServiceMetrics = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web-metrics
+`
+
+func TestNoResourceRemovedValidatorWhenOk(t *testing.T) {
+	service := makeManifest(docToTestResourceService)
+	metrics := makeManifest(docToTestResourceServiceMetrics)
+
+	validator := NoResourceRemovedValidator{}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:            []common.K8sManifest{service, metrics},
+		AllPreviousDocs: []common.K8sManifest{service},
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestNoResourceRemovedValidatorWhenMovedToOtherTemplate(t *testing.T) {
+	service := makeManifest(docToTestResourceService)
+	metrics := makeManifest(docToTestResourceServiceMetrics)
+
+	validator := NoResourceRemovedValidator{}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:            []common.K8sManifest{service},
+		AllDocs:         []common.K8sManifest{service, metrics},
+		AllPreviousDocs: []common.K8sManifest{service, metrics},
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestNoResourceRemovedValidatorWhenFail(t *testing.T) {
+	service := makeManifest(docToTestResourceService)
+	metrics := makeManifest(docToTestResourceServiceMetrics)
+
+	validator := NoResourceRemovedValidator{}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:            []common.K8sManifest{service},
+		AllPreviousDocs: []common.K8sManifest{service, metrics},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Expected to keep resources:",
+		"\tService/web",
+		"\tService/web-metrics",
+		"Actual:",
+		"\tService/web-metrics removed",
+	}, diff)
+}
+
+func TestNoResourceRemovedValidatorWhenMovedToOtherNamespace(t *testing.T) {
+	previous := makeManifest(docToTestResourceService + "  namespace: monitoring\n")
+	current := makeManifest(docToTestResourceService + "  namespace: default\n")
+
+	validator := NoResourceRemovedValidator{}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:            []common.K8sManifest{current},
+		AllPreviousDocs: []common.K8sManifest{previous},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Expected to keep resources:",
+		"\tService/monitoring/web",
+		"Actual:",
+		"\tService/monitoring/web removed",
+	}, diff)
+}
+
+func TestNoResourceRemovedValidatorWhenNegativeAndOk(t *testing.T) {
+	service := makeManifest(docToTestResourceService)
+	metrics := makeManifest(docToTestResourceServiceMetrics)
+
+	validator := NoResourceRemovedValidator{}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:            []common.K8sManifest{service},
+		AllPreviousDocs: []common.K8sManifest{service, metrics},
+		Negative:        true,
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestNoResourceRemovedValidatorWithoutPreviousRender(t *testing.T) {
+	validator := NoResourceRemovedValidator{}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{makeManifest(docToTestResourceService)},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Error:",
+		"\tno previous render found, define 'upgradeFrom' in the test suite or test job",
+	}, diff)
+}
@@ -129,7 +129,7 @@ func (o operatorValidator) Validate(context *ValidateContext) (bool, []string) {
 	validateErrors := make([]string, 0)
 
 	for manifestIndex, manifest := range manifests {
-		validateManifestSuccess, validateManifestErrors := o.validateManifest(manifest, manifestIndex, context)
+		validateManifestSuccess, validateManifestErrors := o.validateManifest(manifest, context.documentIndex(manifestIndex), context)
 		validateErrors = append(validateErrors, validateManifestErrors...)
 		validateSuccess = determineSuccess(manifestIndex, validateSuccess, validateManifestSuccess)
 
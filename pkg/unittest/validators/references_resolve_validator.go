@@ -0,0 +1,442 @@
+package validators
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+)
+
+// Built-in reference kinds which can be validated by ReferencesResolveValidator.
+const (
+	ServiceSelectorReference = "serviceSelector"
+	IngressBackendReference  = "ingressBackend"
+	ConfigMapReference       = "configMap"
+	SecretReference          = "secret"
+	PVCReference             = "persistentVolumeClaim"
+	ServiceAccountReference  = "serviceAccount"
+)
+
+// AllReferenceKinds the reference kinds validated when no kinds are specified.
+var AllReferenceKinds = []string{
+	ServiceSelectorReference,
+	IngressBackendReference,
+	ConfigMapReference,
+	SecretReference,
+	PVCReference,
+	ServiceAccountReference,
+}
+
+// podSpecPaths the location of the pod template per workload kind.
+var podSpecPaths = map[string][]string{
+	"Pod":                   {},
+	"Deployment":            {"spec", "template"},
+	"StatefulSet":           {"spec", "template"},
+	"DaemonSet":             {"spec", "template"},
+	"ReplicaSet":            {"spec", "template"},
+	"ReplicationController": {"spec", "template"},
+	"Job":                   {"spec", "template"},
+	"CronJob":               {"spec", "jobTemplate", "spec", "template"},
+}
+
+// ReferencesResolveValidator validate all references of the selected documents
+// can be resolved against the documents rendered by the test job.
+type ReferencesResolveValidator struct {
+	Kinds  []string // optional
+	Ignore []string // optional, formatted as Kind/name
+}
+
+// danglingReference a reference which can not be resolved.
+type danglingReference struct {
+	Path   string
+	Target string
+}
+
+func (r danglingReference) String() string {
+	return fmt.Sprintf("%s: %s not found", r.Path, r.Target)
+}
+
+func joinDangling(dangling []danglingReference) string {
+	lines := make([]string, len(dangling))
+	for i, reference := range dangling {
+		lines[i] = reference.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (v ReferencesResolveValidator) failInfo(identity, actual string, manifestIndex int, not bool) []string {
+	customMessage := " references to resolve"
+
+	log.WithField("validator", "references_resolve").Debugln("expected content:", identity)
+	log.WithField("validator", "references_resolve").Debugln("actual content:", actual)
+
+	if not {
+		return splitInfof(
+			setFailFormat(not, false, false, false, customMessage),
+			manifestIndex,
+			-1,
+			identity,
+		)
+	}
+	return splitInfof(
+		setFailFormat(not, false, true, false, customMessage),
+		manifestIndex,
+		-1,
+		identity,
+		actual,
+	)
+}
+
+// Validate implement Validatable
+func (v ReferencesResolveValidator) Validate(context *ValidateContext) (bool, []string) {
+	manifests := context.getManifests()
+	targets := context.AllDocs
+	if targets == nil {
+		targets = context.Docs
+	}
+
+	validateSuccess := false
+	validateErrors := make([]string, 0)
+
+	for manifestIndex, manifest := range manifests {
+		dangling := v.danglingReferences(manifest, targets)
+		if (len(dangling) == 0) == context.Negative {
+			validateSuccess = false
+			errorMessage := v.failInfo(manifestIdentity(manifest), joinDangling(dangling), context.documentIndex(manifestIndex), context.Negative)
+			validateErrors = append(validateErrors, errorMessage...)
+			if context.FailFast {
+				break
+			}
+			continue
+		}
+
+		validateSuccess = determineSuccess(manifestIndex, validateSuccess, true)
+	}
+
+	if len(manifests) == 0 && !context.Negative {
+		errorMessage := v.failInfo(strings.Join(v.checkedKinds(), ", "), "no manifest found", -1, context.Negative)
+		validateErrors = append(validateErrors, errorMessage...)
+	} else if len(manifests) == 0 && context.Negative {
+		validateSuccess = true
+	}
+
+	return validateSuccess, validateErrors
+}
+
+// danglingReferences collects all references of the manifest which can not be resolved in targets.
+func (v ReferencesResolveValidator) danglingReferences(manifest common.K8sManifest, targets []common.K8sManifest) []danglingReference {
+	dangling := make([]danglingReference, 0)
+	kind, _ := manifest["kind"].(string)
+	namespace := manifestNamespace(manifest)
+
+	if v.checks(ServiceSelectorReference) && kind == "Service" {
+		dangling = append(dangling, v.serviceSelectorReferences(manifest, namespace, targets)...)
+	}
+
+	if v.checks(IngressBackendReference) && kind == "Ingress" {
+		dangling = append(dangling, v.ingressBackendReferences(manifest, namespace, targets)...)
+	}
+
+	if prefix, ok := podSpecPaths[kind]; ok {
+		podSpecPath := append(slices.Clone(prefix), "spec")
+		if podSpec, ok := common.NestedMap(manifest, podSpecPath...); ok {
+			dangling = append(dangling, v.podSpecReferences(podSpec, joinPath(podSpecPath), namespace, targets)...)
+		}
+	}
+
+	return dangling
+}
+
+func (v ReferencesResolveValidator) checks(referenceKind string) bool {
+	if len(v.Kinds) == 0 {
+		return true
+	}
+	return slices.Contains(v.Kinds, referenceKind)
+}
+
+func (v ReferencesResolveValidator) checkedKinds() []string {
+	if len(v.Kinds) == 0 {
+		return AllReferenceKinds
+	}
+	return v.Kinds
+}
+
+func (v ReferencesResolveValidator) ignored(kind, name string) bool {
+	return slices.Contains(v.Ignore, kind+"/"+name)
+}
+
+// resolve returns the first target of kind and name within the namespace.
+func (v ReferencesResolveValidator) resolve(kind, name, namespace string, targets []common.K8sManifest) (common.K8sManifest, bool) {
+	for _, target := range targets {
+		if targetKind, _ := target["kind"].(string); targetKind != kind {
+			continue
+		}
+		if targetName, _ := common.NestedString(target, "metadata", "name"); targetName != name {
+			continue
+		}
+		if !namespaceMatches(namespace, manifestNamespace(target)) {
+			continue
+		}
+		return target, true
+	}
+	return nil, false
+}
+
+// serviceSelectorReferences validates the selector of a service matches the pod labels of at least one workload.
+func (v ReferencesResolveValidator) serviceSelectorReferences(manifest common.K8sManifest, namespace string, targets []common.K8sManifest) []danglingReference {
+	if serviceType, _ := common.NestedString(manifest, "spec", "type"); serviceType == "ExternalName" {
+		return nil
+	}
+	selector, ok := common.NestedMap(manifest, "spec", "selector")
+	if !ok || len(selector) == 0 {
+		return nil
+	}
+
+	for _, target := range targets {
+		targetKind, _ := target["kind"].(string)
+		prefix, ok := podSpecPaths[targetKind]
+		if !ok || !namespaceMatches(namespace, manifestNamespace(target)) {
+			continue
+		}
+		labels, _ := common.NestedMap(target, append(slices.Clone(prefix), "metadata", "labels")...)
+		if labelsMatch(selector, labels) {
+			return nil
+		}
+	}
+
+	return []danglingReference{{
+		Path:   "spec.selector",
+		Target: fmt.Sprintf("pods with labels %s", formatLabels(selector)),
+	}}
+}
+
+// ingressBackendReferences validates all backends of an ingress point at a rendered service port.
+func (v ReferencesResolveValidator) ingressBackendReferences(manifest common.K8sManifest, namespace string, targets []common.K8sManifest) []danglingReference {
+	dangling := make([]danglingReference, 0)
+
+	checkBackend := func(backend map[string]interface{}, path string) {
+		serviceName, port, portPath, ok := ingressBackendService(backend, path)
+		if !ok || v.ignored("Service", serviceName) {
+			return
+		}
+		service, found := v.resolve("Service", serviceName, namespace, targets)
+		if !found {
+			dangling = append(dangling, danglingReference{Path: path, Target: fmt.Sprintf("Service %q", serviceName)})
+			return
+		}
+		if port != nil && !servicePortExists(service, port) {
+			dangling = append(dangling, danglingReference{Path: portPath, Target: fmt.Sprintf("port %v of Service %q", port, serviceName)})
+		}
+	}
+
+	if backend, ok := common.NestedMap(manifest, "spec", "defaultBackend"); ok {
+		checkBackend(backend, "spec.defaultBackend")
+	}
+	if backend, ok := common.NestedMap(manifest, "spec", "backend"); ok {
+		checkBackend(backend, "spec.backend")
+	}
+
+	rules, _ := common.NestedSlice(manifest, "spec", "rules")
+	for ruleIndex, rule := range rules {
+		paths, _ := common.NestedSlice(rule, "http", "paths")
+		for pathIndex, ingressPath := range paths {
+			if backend, ok := common.NestedMap(ingressPath, "backend"); ok {
+				checkBackend(backend, fmt.Sprintf("spec.rules[%d].http.paths[%d].backend", ruleIndex, pathIndex))
+			}
+		}
+	}
+
+	return dangling
+}
+
+// podSpecReferences validates volumes, environment and service account references of a pod spec.
+func (v ReferencesResolveValidator) podSpecReferences(podSpec map[string]interface{}, path, namespace string, targets []common.K8sManifest) []danglingReference {
+	dangling := make([]danglingReference, 0)
+
+	check := func(referenceKind, kind, name, key, referencePath string, optional bool) {
+		if !v.checks(referenceKind) || name == "" || optional || v.ignored(kind, name) {
+			return
+		}
+		target, found := v.resolve(kind, name, namespace, targets)
+		if !found {
+			dangling = append(dangling, danglingReference{Path: referencePath, Target: fmt.Sprintf("%s %q", kind, name)})
+			return
+		}
+		if key != "" && !dataKeyExists(target, key) {
+			dangling = append(dangling, danglingReference{Path: referencePath, Target: fmt.Sprintf("key %q in %s %q", key, kind, name)})
+		}
+	}
+
+	if serviceAccountName, ok := podSpec["serviceAccountName"].(string); ok && serviceAccountName != "default" {
+		check(ServiceAccountReference, "ServiceAccount", serviceAccountName, "", path+".serviceAccountName", false)
+	}
+
+	volumes, _ := common.NestedSlice(podSpec, "volumes")
+	for volumeIndex, volume := range volumes {
+		volumePath := fmt.Sprintf("%s.volumes[%d]", path, volumeIndex)
+		if source, ok := common.NestedMap(volume, "configMap"); ok {
+			name, _ := source["name"].(string)
+			check(ConfigMapReference, "ConfigMap", name, "", volumePath+".configMap.name", isOptional(source))
+		}
+		if source, ok := common.NestedMap(volume, "secret"); ok {
+			name, _ := source["secretName"].(string)
+			check(SecretReference, "Secret", name, "", volumePath+".secret.secretName", isOptional(source))
+		}
+		if source, ok := common.NestedMap(volume, "persistentVolumeClaim"); ok {
+			name, _ := source["claimName"].(string)
+			check(PVCReference, "PersistentVolumeClaim", name, "", volumePath+".persistentVolumeClaim.claimName", false)
+		}
+		projected, _ := common.NestedSlice(volume, "projected", "sources")
+		for sourceIndex, projectedSource := range projected {
+			sourcePath := fmt.Sprintf("%s.projected.sources[%d]", volumePath, sourceIndex)
+			if source, ok := common.NestedMap(projectedSource, "configMap"); ok {
+				name, _ := source["name"].(string)
+				check(ConfigMapReference, "ConfigMap", name, "", sourcePath+".configMap.name", isOptional(source))
+			}
+			if source, ok := common.NestedMap(projectedSource, "secret"); ok {
+				name, _ := source["name"].(string)
+				check(SecretReference, "Secret", name, "", sourcePath+".secret.name", isOptional(source))
+			}
+		}
+	}
+
+	for _, containerType := range []string{"initContainers", "containers"} {
+		containers, _ := common.NestedSlice(podSpec, containerType)
+		for containerIndex, container := range containers {
+			containerPath := fmt.Sprintf("%s.%s[%d]", path, containerType, containerIndex)
+
+			envFrom, _ := common.NestedSlice(container, "envFrom")
+			for envIndex, envSource := range envFrom {
+				envPath := fmt.Sprintf("%s.envFrom[%d]", containerPath, envIndex)
+				if source, ok := common.NestedMap(envSource, "configMapRef"); ok {
+					name, _ := source["name"].(string)
+					check(ConfigMapReference, "ConfigMap", name, "", envPath+".configMapRef.name", isOptional(source))
+				}
+				if source, ok := common.NestedMap(envSource, "secretRef"); ok {
+					name, _ := source["name"].(string)
+					check(SecretReference, "Secret", name, "", envPath+".secretRef.name", isOptional(source))
+				}
+			}
+
+			env, _ := common.NestedSlice(container, "env")
+			for envIndex, envVar := range env {
+				envPath := fmt.Sprintf("%s.env[%d].valueFrom", containerPath, envIndex)
+				if source, ok := common.NestedMap(envVar, "valueFrom", "configMapKeyRef"); ok {
+					name, _ := source["name"].(string)
+					key, _ := source["key"].(string)
+					check(ConfigMapReference, "ConfigMap", name, key, envPath+".configMapKeyRef", isOptional(source))
+				}
+				if source, ok := common.NestedMap(envVar, "valueFrom", "secretKeyRef"); ok {
+					name, _ := source["name"].(string)
+					key, _ := source["key"].(string)
+					check(SecretReference, "Secret", name, key, envPath+".secretKeyRef", isOptional(source))
+				}
+			}
+		}
+	}
+
+	return dangling
+}
+
+// ingressBackendService returns the service name and port of both the networking.k8s.io/v1
+// and the legacy extensions/v1beta1 backend formats.
+func ingressBackendService(backend map[string]interface{}, path string) (string, interface{}, string, bool) {
+	if service, ok := common.NestedMap(backend, "service"); ok {
+		name, _ := service["name"].(string)
+		if number, ok := common.NestedValue(service, "port", "number"); ok {
+			return name, number, path + ".service.port.number", name != ""
+		}
+		if portName, ok := common.NestedValue(service, "port", "name"); ok {
+			return name, portName, path + ".service.port.name", name != ""
+		}
+		return name, nil, "", name != ""
+	}
+	if name, ok := backend["serviceName"].(string); ok {
+		port, hasPort := backend["servicePort"]
+		if !hasPort {
+			return name, nil, "", true
+		}
+		return name, port, path + ".servicePort", true
+	}
+	return "", nil, "", false
+}
+
+// servicePortExists validates the port (number or name) is exposed by the service.
+func servicePortExists(service common.K8sManifest, port interface{}) bool {
+	ports, _ := common.NestedSlice(service, "spec", "ports")
+	for _, servicePort := range ports {
+		if name, ok := port.(string); ok {
+			if servicePort["name"] == name {
+				return true
+			}
+			continue
+		}
+		if fmt.Sprintf("%v", servicePort["port"]) == fmt.Sprintf("%v", port) {
+			return true
+		}
+	}
+	return false
+}
+
+// dataKeyExists validates the key exists in either data or binaryData (or stringData for secrets).
+func dataKeyExists(target common.K8sManifest, key string) bool {
+	for _, field := range []string{"data", "binaryData", "stringData"} {
+		if data, ok := common.NestedMap(target, field); ok {
+			if _, ok := data[key]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isOptional(source map[string]interface{}) bool {
+	optional, _ := source["optional"].(bool)
+	return optional
+}
+
+func labelsMatch(selector, labels map[string]interface{}) bool {
+	if labels == nil {
+		return false
+	}
+	for key, value := range selector {
+		if fmt.Sprintf("%v", labels[key]) != fmt.Sprintf("%v", value) {
+			return false
+		}
+	}
+	return true
+}
+
+func formatLabels(labels map[string]interface{}) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", key, value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// manifestIdentity returns the Kind/name of the manifest.
+func manifestIdentity(manifest common.K8sManifest) string {
+	kind, _ := manifest["kind"].(string)
+	name, _ := common.NestedString(manifest, "metadata", "name")
+	return fmt.Sprintf("%s/%s", kind, name)
+}
+
+func manifestNamespace(manifest common.K8sManifest) string {
+	namespace, _ := common.NestedString(manifest, "metadata", "namespace")
+	return namespace
+}
+
+// namespaceMatches an unset namespace is rendered in the release namespace and matches any namespace.
+func namespaceMatches(source, target string) bool {
+	return source == "" || target == "" || source == target
+}
+
+func joinPath(path []string) string {
+	return strings.Join(path, ".")
+}
@@ -0,0 +1,306 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/internal/common"
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+var docToTestReferencesDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      serviceAccountName: web
+      containers:
+        - name: web
+          envFrom:
+            - configMapRef:
+                name: web-env
+          env:
+            - name: PASSWORD
+              valueFrom:
+                secretKeyRef:
+                  name: web-secret
+                  key: password
+      volumes:
+        - name: config
+          configMap:
+            name: web-config
+        - name: optional
+          secret:
+            secretName: not-rendered
+            optional: true
+`
+
+var docToTestReferencesService = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  selector:
+    app: web
+  ports:
+    - name: http
+      port: 80
+`
+
+var docToTestReferencesIngress = `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web
+spec:
+  rules:
+    - http:
+        paths:
+          - path: /
+            backend:
+              service:
+                name: web
+                port:
+                  number: 80
+          - path: /api
+            backend:
+              service:
+                name: web
+                port:
+                  name: http
+`
+
+var docToTestReferencesConfigMapEnv = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web-env
+data:
+  FOO: bar
+`
+
+var docToTestReferencesConfigMap = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web-config
+data:
+  config.yaml: ""
+`
+
+var docToTestReferencesSecret = `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: web-secret
+stringData:
+  password: secret
+`
+
+var docToTestReferencesServiceAccount = `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: web
+`
+
+func referencesResolveAllDocs() []common.K8sManifest {
+	return []common.K8sManifest{
+		makeManifest(docToTestReferencesDeployment),
+		makeManifest(docToTestReferencesService),
+		makeManifest(docToTestReferencesIngress),
+		makeManifest(docToTestReferencesConfigMapEnv),
+		makeManifest(docToTestReferencesConfigMap),
+		makeManifest(docToTestReferencesSecret),
+		makeManifest(docToTestReferencesServiceAccount),
+	}
+}
+
+func TestReferencesResolveValidatorOk(t *testing.T) {
+	allDocs := referencesResolveAllDocs()
+
+	validator := ReferencesResolveValidator{}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:    allDocs,
+		AllDocs: allDocs,
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestReferencesResolveValidatorWhenDanglingFail(t *testing.T) {
+	deployment := makeManifest(docToTestReferencesDeployment)
+	allDocs := []common.K8sManifest{deployment, makeManifest(docToTestReferencesSecret)}
+
+	validator := ReferencesResolveValidator{}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:    []common.K8sManifest{deployment},
+		AllDocs: allDocs,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Expected references to resolve:",
+		"\tDeployment/web",
+		"Actual:",
+		"\tspec.template.spec.serviceAccountName: ServiceAccount \"web\" not found",
+		"\tspec.template.spec.volumes[0].configMap.name: ConfigMap \"web-config\" not found",
+		"\tspec.template.spec.containers[0].envFrom[0].configMapRef.name: ConfigMap \"web-env\" not found",
+	}, diff)
+}
+
+func TestReferencesResolveValidatorWhenMissingKeyFail(t *testing.T) {
+	deployment := makeManifest(docToTestReferencesDeployment)
+	secret := makeManifest(`
+apiVersion: v1
+kind: Secret
+metadata:
+  name: web-secret
+data:
+  username: dXNlcg==
+`)
+
+	validator := ReferencesResolveValidator{Kinds: []string{SecretReference}}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:    []common.K8sManifest{deployment},
+		AllDocs: []common.K8sManifest{deployment, secret},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Expected references to resolve:",
+		"\tDeployment/web",
+		"Actual:",
+		"\tspec.template.spec.containers[0].env[0].valueFrom.secretKeyRef: key \"password\" in Secret \"web-secret\" not found",
+	}, diff)
+}
+
+func TestReferencesResolveValidatorWhenServiceSelectorFail(t *testing.T) {
+	service := makeManifest(docToTestReferencesService)
+	deployment := makeManifest(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    metadata:
+      labels:
+        app: api
+`)
+
+	validator := ReferencesResolveValidator{}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:    []common.K8sManifest{service},
+		AllDocs: []common.K8sManifest{service, deployment},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Expected references to resolve:",
+		"\tService/web",
+		"Actual:",
+		"\tspec.selector: pods with labels app=web not found",
+	}, diff)
+}
+
+func TestReferencesResolveValidatorWhenIngressPortFail(t *testing.T) {
+	ingress := makeManifest(docToTestReferencesIngress)
+	service := makeManifest(`
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  ports:
+    - name: https
+      port: 443
+`)
+
+	validator := ReferencesResolveValidator{}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:    []common.K8sManifest{ingress},
+		AllDocs: []common.K8sManifest{ingress, service},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Expected references to resolve:",
+		"\tIngress/web",
+		"Actual:",
+		"\tspec.rules[0].http.paths[0].backend.service.port.number: port 80 of Service \"web\" not found",
+		"\tspec.rules[0].http.paths[1].backend.service.port.name: port http of Service \"web\" not found",
+	}, diff)
+}
+
+func TestReferencesResolveValidatorWithIgnoreOk(t *testing.T) {
+	ingress := makeManifest(docToTestReferencesIngress)
+
+	validator := ReferencesResolveValidator{Ignore: []string{"Service/web"}}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:    []common.K8sManifest{ingress},
+		AllDocs: []common.K8sManifest{ingress},
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestReferencesResolveValidatorWhenNegativeAndOk(t *testing.T) {
+	deployment := makeManifest(docToTestReferencesDeployment)
+
+	validator := ReferencesResolveValidator{}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:     []common.K8sManifest{deployment},
+		AllDocs:  []common.K8sManifest{deployment},
+		Negative: true,
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+}
+
+func TestReferencesResolveValidatorWhenNegativeAndFail(t *testing.T) {
+	allDocs := referencesResolveAllDocs()
+
+	validator := ReferencesResolveValidator{}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:     allDocs[:1],
+		AllDocs:  allDocs,
+		Negative: true,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"Expected NOT references to resolve:",
+		"\tDeployment/web",
+	}, diff)
+}
+
+func TestReferencesResolveValidatorWhenNoManifestFail(t *testing.T) {
+	validator := ReferencesResolveValidator{Kinds: []string{ConfigMapReference}}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs: []common.K8sManifest{},
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Expected references to resolve:",
+		"\tconfigMap",
+		"Actual:",
+		"\tno manifest found",
+	}, diff)
+}
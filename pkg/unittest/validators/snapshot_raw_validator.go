@@ -1,24 +1,27 @@
 package validators
 
 import (
-	"strconv"
-
 	"github.com/helm-unittest/helm-unittest/internal/common"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
 	log "github.com/sirupsen/logrus"
 )
 
 // MatchSnapshotRawValidator validate snapshot of value of Path the same as cached
-type MatchSnapshotRawValidator struct{}
+type MatchSnapshotRawValidator struct {
+	SnapshotName string
+}
 
 func (v MatchSnapshotRawValidator) failInfo(compared *snapshot.CompareResult, not bool) []string {
-	customMessage := " to match snapshot " + strconv.Itoa(int(compared.Index))
+	customMessage := " to match snapshot " + snapshotLabel(compared)
 
 	log.WithField("validator", "snapshot_raw").Debugln("expected content:", compared.CachedSnapshot)
 	log.WithField("validator", "snapshot_raw").Debugln("actual content:", compared.NewSnapshot)
 
 	var infoToShow string
-	if not {
+	if compared.Msg != "" {
+		customMessage = compared.Msg
+		infoToShow = compared.CachedSnapshot
+	} else if not {
 		infoToShow = compared.CachedSnapshot
 	} else {
 		infoToShow = diff(compared.CachedSnapshot, compared.NewSnapshot)
@@ -43,7 +46,9 @@ func (v MatchSnapshotRawValidator) Validate(context *ValidateContext) (bool, []s
 		var errorMessage []string
 		actual := uniformContent(manifest[common.RAW])
 
-		result := context.CompareToSnapshot(actual)
+		result := context.CompareToSnapshot(actual,
+			snapshot.WithName(snapshotName(v.SnapshotName, context.Template, manifest, "")),
+			snapshot.WithDocument(snapshotDocument(context.Template, manifest)))
 
 		if result.Passed == context.Negative {
 			errorMessage = v.failInfo(result, context.Negative)
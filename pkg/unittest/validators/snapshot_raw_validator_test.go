@@ -135,3 +135,25 @@ func TestSnapshotRawValidatorWhenNoManifestNegativeFail(t *testing.T) {
 	assert.False(t, pass)
 	assert.Equal(t, []string{}, diff)
 }
+
+func TestSnapshotRawValidatorWhenMissingInCI(t *testing.T) {
+	data := common.K8sManifest{common.RAW: "b"}
+	validator := MatchSnapshotRawValidator{}
+
+	mockComparer := new(mockSnapshotComparer)
+	mockComparer.On("CompareToSnapshot", "b").Return(&snapshot.CompareResult{
+		Passed: false,
+		Msg:    " snapshot NOTES.txt to be stored, new snapshots are not stored in CI mode",
+	})
+
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:             []common.K8sManifest{data},
+		SnapshotComparer: mockComparer,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"Expected snapshot NOTES.txt to be stored, new snapshots are not stored in CI mode:",
+		"\t",
+	}, diff)
+}
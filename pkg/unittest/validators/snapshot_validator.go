@@ -3,6 +3,7 @@ package validators
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/helm-unittest/helm-unittest/internal/common"
 	"github.com/helm-unittest/helm-unittest/pkg/unittest/snapshot"
@@ -21,8 +22,13 @@ type snapshotValidation string
 // MatchSnapshotValidator validate snapshot of value of Path the same as cached
 type MatchSnapshotValidator struct {
 	Path          string
+	SnapshotName  string
 	MatchRegex    *MatchRegex
 	NotMatchRegex *NotMatchRegex
+	// Ignore the paths of the document removed before comparing
+	Ignore []string
+	// Redact the paths of the document replaced with a placeholder before comparing
+	Redact []string
 }
 
 type MatchRegex struct {
@@ -47,10 +53,12 @@ func (v MatchSnapshotValidator) failInfo(compared *snapshot.CompareResult, manif
 			compared.CachedSnapshot,
 		)
 	} else {
-		msg := fmt.Sprintf(" to match snapshot %s", strconv.Itoa(int(compared.Index)))
+		msg := fmt.Sprintf(" to match snapshot %s", snapshotLabel(compared))
 		var infoToShow string
 		if not {
 			infoToShow = compared.CachedSnapshot
+		} else if compared.Diff != "" {
+			infoToShow = compared.Diff
 		} else {
 			infoToShow = diff(compared.CachedSnapshot, compared.NewSnapshot)
 		}
@@ -66,7 +74,14 @@ func (v MatchSnapshotValidator) failInfo(compared *snapshot.CompareResult, manif
 }
 
 func (v MatchSnapshotValidator) validateManifest(manifest common.K8sManifest, manifestIndex int, context *ValidateContext) (bool, []string) {
-	actual, err := valueutils.GetValueOfSetPath(manifest, v.Path)
+	// The redactions are relative to the document, so they are applied before the path is selected.
+	redaction := context.SnapshotRedaction.Merge(snapshot.Redaction{Ignore: v.Ignore, Redact: v.Redact})
+	redacted, err := redaction.Apply(manifest)
+	if err != nil {
+		return false, splitInfof(errorFormat, manifestIndex, -1, err.Error())
+	}
+
+	actual, err := valueutils.GetValueOfSetPath(redacted, v.Path)
 	if err != nil {
 		return false, splitInfof(errorFormat, manifestIndex, -1, err.Error())
 	}
@@ -89,7 +104,9 @@ func (v MatchSnapshotValidator) validateManifest(manifest common.K8sManifest, ma
 		if v.NotMatchRegex != nil && v.NotMatchRegex.Pattern != "" {
 			withNotMatchRegex = snapshot.WithNotMatchRegexPattern(v.NotMatchRegex.Pattern)
 		}
-		result := context.CompareToSnapshot(singleActual, withMatchRegex, withNotMatchRegex)
+		withName := snapshot.WithName(snapshotName(v.SnapshotName, context.Template, manifest, v.Path))
+		withDocument := snapshot.WithDocument(snapshotDocument(context.Template, manifest))
+		result := context.CompareToSnapshot(singleActual, withName, withDocument, withMatchRegex, withNotMatchRegex)
 
 		if result.Err != nil {
 			return false, splitInfof(errorFormat, manifestIndex, actualIndex, fmt.Sprintf("%v", err))
@@ -119,7 +136,7 @@ func (v MatchSnapshotValidator) Validate(context *ValidateContext) (bool, []stri
 	validateErrors := make([]string, 0)
 
 	for manifestIndex, manifest := range manifests {
-		validateManifestSuccess, validateManifestErrors := v.validateManifest(manifest, manifestIndex, context)
+		validateManifestSuccess, validateManifestErrors := v.validateManifest(manifest, context.documentIndex(manifestIndex), context)
 		validateErrors = append(validateErrors, validateManifestErrors...)
 		validateSuccess = determineSuccess(manifestIndex, validateSuccess, validateManifestSuccess)
 
@@ -130,3 +147,50 @@ func (v MatchSnapshotValidator) Validate(context *ValidateContext) (bool, []stri
 
 	return validateSuccess, validateErrors
 }
+
+// snapshotName returns the name of the snapshot, when not defined the name is derived from the template,
+// the kind and name of the document and the path.
+func snapshotName(name, template string, manifest common.K8sManifest, path string) string {
+	if name != "" {
+		return name
+	}
+
+	parts := make([]string, 0, 3)
+	if template != "" {
+		parts = append(parts, template)
+	}
+	if document := documentOf(manifest); document != "" {
+		parts = append(parts, document)
+	}
+	if path != "" {
+		parts = append(parts, path)
+	}
+	return strings.Join(parts, " ")
+}
+
+// snapshotDocument returns the document of the snapshot, the kind and name of the document,
+// or the template for documents without kind and name.
+func snapshotDocument(template string, manifest common.K8sManifest) string {
+	if document := documentOf(manifest); document != "" {
+		return document
+	}
+	return template
+}
+
+// documentOf returns the kind and name of the document, like `Deployment/my-app`.
+func documentOf(manifest common.K8sManifest) string {
+	kind, _ := common.NestedString(manifest, "kind")
+	documentName, _ := common.NestedString(manifest, "metadata", "name")
+	if kind == "" && documentName == "" {
+		return ""
+	}
+	return kind + "/" + documentName
+}
+
+// snapshotLabel returns the name of the compared snapshot, or the index for unnamed snapshots.
+func snapshotLabel(compared *snapshot.CompareResult) string {
+	if compared.Key != "" {
+		return compared.Key
+	}
+	return strconv.Itoa(int(compared.Index))
+}
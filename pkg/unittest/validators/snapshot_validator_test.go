@@ -1,6 +1,7 @@
 package validators_test
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/helm-unittest/helm-unittest/internal/common"
@@ -322,3 +323,120 @@ func TestSnapshotValidatorWheNotMatchRegexFail(t *testing.T) {
 	assert.False(t, pass)
 	assert.Equal(t, []string{"DocumentIndex:\t0", "ValuesIndex:\t0", "Expected pattern '.*abra.*' should not be in snapshot:", "\ta: abrakadabra"}, diff)
 }
+
+type cacheComparer struct {
+	cache *snapshot.Cache
+	index uint
+}
+
+func (c *cacheComparer) CompareToSnapshot(content interface{}, optFns ...func(options *snapshot.CacheOptions) error) *snapshot.CompareResult {
+	c.index++
+	return c.cache.Compare("test", c.index, content, optFns...)
+}
+
+func TestSnapshotValidatorWhenNamedByDocument(t *testing.T) {
+	cacheFile := filepath.Join(t.TempDir(), "test.yaml.snap")
+	validate := func(docs ...common.K8sManifest) (bool, []string) {
+		cache := &snapshot.Cache{Filepath: cacheFile}
+		assert.NoError(t, cache.RestoreFromFile())
+		defer func() {
+			_, err := cache.StoreToFileIfNeeded()
+			assert.NoError(t, err)
+		}()
+		return MatchSnapshotValidator{Path: "spec"}.Validate(&ValidateContext{
+			Template:         "templates/deployment.yaml",
+			Docs:             docs,
+			SnapshotComparer: &cacheComparer{cache: cache},
+		})
+	}
+	first := makeManifest("kind: Deployment\nmetadata:\n  name: first\nspec:\n  replicas: 1\n")
+	second := makeManifest("kind: Deployment\nmetadata:\n  name: second\nspec:\n  replicas: 1\n")
+
+	pass, _ := validate(first, second)
+	assert.True(t, pass)
+
+	// The order of the documents no longer affects the snapshots.
+	changed := makeManifest("kind: Deployment\nmetadata:\n  name: first\nspec:\n  replicas: 2\n")
+	pass, diff := validate(second, changed)
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t1",
+		"ValuesIndex:\t0",
+		"Path:\tspec",
+		"Expected to match snapshot templates/deployment.yaml Deployment/first spec:",
+		"\t--- Expected",
+		"\t+++ Actual",
+		"\t@@ -1,2 +1,2 @@",
+		"\t-replicas: 1",
+		"\t+replicas: 2",
+	}, diff)
+}
+
+func TestSnapshotValidatorWhenSnapshotName(t *testing.T) {
+	mockComparer := new(namedSnapshotComparer)
+	validator := MatchSnapshotValidator{Path: "a", SnapshotName: "custom"}
+	pass, _ := validator.Validate(&ValidateContext{
+		Template:         "templates/deployment.yaml",
+		Docs:             []common.K8sManifest{makeManifest("a: b")},
+		SnapshotComparer: mockComparer,
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{"custom"}, mockComparer.names)
+}
+
+type namedSnapshotComparer struct {
+	names []string
+}
+
+func (c *namedSnapshotComparer) CompareToSnapshot(_ interface{}, optFns ...func(options *snapshot.CacheOptions) error) *snapshot.CompareResult {
+	var options snapshot.CacheOptions
+	for _, optFn := range optFns {
+		_ = optFn(&options)
+	}
+	c.names = append(c.names, options.Name)
+	return &snapshot.CompareResult{Passed: true}
+}
+
+func TestSnapshotValidatorWhenFailWithStructuralDiff(t *testing.T) {
+	data := common.K8sManifest{"a": "b"}
+	mockComparer := new(mockSnapshotComparer)
+	mockComparer.On("CompareToSnapshot", "b").Return(&snapshot.CompareResult{
+		Passed:         false,
+		CachedSnapshot: "x\n",
+		NewSnapshot:    "b\n",
+		Diff:           `.: "x" -> "b"`,
+	})
+
+	validator := MatchSnapshotValidator{Path: "a"}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:             []common.K8sManifest{data},
+		SnapshotComparer: mockComparer,
+	})
+
+	assert.False(t, pass)
+	assert.Equal(t, []string{
+		"DocumentIndex:\t0",
+		"ValuesIndex:\t0",
+		"Path:\ta",
+		"Expected to match snapshot 0:",
+		"\t.: \"x\" -> \"b\"",
+	}, diff)
+}
+
+func TestSnapshotValidatorWhenRedacted(t *testing.T) {
+	data := makeManifest("metadata:\n  annotations:\n    checksum/config: abc\ndata:\n  tls.crt: cert\n  tls.key: key\n")
+	mockComparer := new(mockSnapshotComparer)
+	mockComparer.On("CompareToSnapshot", map[string]interface{}{"tls.key": snapshot.RedactedValue}).Return(&snapshot.CompareResult{Passed: true})
+
+	validator := MatchSnapshotValidator{Path: "data", Ignore: []string{`data.tls\.crt`}}
+	pass, diff := validator.Validate(&ValidateContext{
+		Docs:              []common.K8sManifest{data},
+		SnapshotComparer:  mockComparer,
+		SnapshotRedaction: snapshot.Redaction{Redact: []string{`data.tls\.key`}},
+	})
+
+	assert.True(t, pass)
+	assert.Equal(t, []string{}, diff)
+	assert.Equal(t, "cert", data["data"].(common.K8sManifest)["tls.crt"])
+}
@@ -0,0 +1,17 @@
+package validators
+
+// TemplateError the structured error of a failed render, asserted by failedTemplate.
+type TemplateError struct {
+	// Template the template of the error, the innermost template of the include and tpl chain which is known
+	Template string
+	// Line the line of the error in the template, 0 when unknown
+	Line int
+	// Column the column of the error in the template, 0 when unknown
+	Column int
+	// Functions the chain of the functions which called the failing template, like include and tpl
+	Functions []string
+	// Message the message of the error, without the location and the chain
+	Message string
+	// SchemaErrors the JSON schema violations, when the values do not meet the schema of the chart
+	SchemaErrors []string
+}
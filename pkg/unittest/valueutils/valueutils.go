@@ -72,6 +72,47 @@ func BuildValueOfSetPath(val interface{}, path string) (map[string]interface{},
 	return tr.getBuildedData(), nil
 }
 
+// UnsetValueOfSetPath remove the value of the `--set` format path from the values, missing paths are ignored
+func UnsetValueOfSetPath(values map[string]interface{}, path string) error {
+	if path == "" {
+		return fmt.Errorf("unset path is empty")
+	}
+	tr := buildTraverser{}
+	reader := bytes.NewBufferString(path)
+	if err := traverseSetPath(reader, &tr, expectKey); err != nil {
+		return err
+	}
+	unsetCursors(values, tr.cursors)
+	return nil
+}
+
+// unsetCursors removes the value at the cursors and returns the changed value, lists are returned without the element.
+func unsetCursors(current interface{}, cursors []interface{}) interface{} {
+	switch typed := current.(type) {
+	case map[string]interface{}:
+		key, isString := cursors[0].(string)
+		next, exists := typed[key]
+		if !isString || !exists {
+			return current
+		}
+		if len(cursors) == 1 {
+			delete(typed, key)
+		} else {
+			typed[key] = unsetCursors(next, cursors[1:])
+		}
+	case []interface{}:
+		idx, isInt := cursors[0].(int)
+		if !isInt || idx >= len(typed) {
+			return current
+		}
+		if len(cursors) == 1 {
+			return append(typed[:idx:idx], typed[idx+1:]...)
+		}
+		typed[idx] = unsetCursors(typed[idx], cursors[1:])
+	}
+	return current
+}
+
 // MatchesPattern checks if the input string matches the given regex pattern.
 // This method is useful for validating input against a specific format or structure when pattern is only provided at runtime.
 // Note: Compiling a regex pattern each time this function is called can be a performance hit.
@@ -618,3 +618,28 @@ func TestMatchesPattern(t *testing.T) {
 		})
 	}
 }
+
+func TestUnsetValueOfSetPath(t *testing.T) {
+	values := map[string]interface{}{
+		"a": map[string]interface{}{
+			"b":   []interface{}{"_", map[string]interface{}{"c": "yes", "d": "no"}, "last"},
+			"e.f": "false",
+		},
+		"g": "keep",
+	}
+
+	for _, path := range []string{"a.b[1].c", "a.b[2]", "a.[e.f]", "a.missing.path", "g[0]"} {
+		assert.NoError(t, UnsetValueOfSetPath(values, path))
+	}
+	assert.Equal(t, map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": []interface{}{"_", map[string]interface{}{"d": "no"}},
+		},
+		"g": "keep",
+	}, values)
+}
+
+func TestUnsetValueOfSetPath_Error(t *testing.T) {
+	assert.EqualError(t, UnsetValueOfSetPath(map[string]interface{}{}, ""), "unset path is empty")
+	assert.EqualError(t, UnsetValueOfSetPath(map[string]interface{}{}, "{"), "invalid token found {")
+}
@@ -0,0 +1,96 @@
+package unittest
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// envVarPattern matches the `${VAR}` placeholders
+var envVarPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// templateVarPattern matches the `{{ .Env.VAR }}` and `{{ .Vars.VAR }}` placeholders
+var templateVarPattern = regexp.MustCompile(`\{\{-?\s*\.(Env|Vars)\.([A-Za-z_][A-Za-z0-9_]*)\s*-?\}\}`)
+
+// substituteVars replaces the placeholders in the content of values. The `${VAR}` placeholders are replaced with the
+// variable of the suite or the environment variable, the placeholders of undefined variables are kept, as they can
+// be part of a script in the values. The `{{ .Env.VAR }}` and `{{ .Vars.VAR }}` placeholders are replaced with the
+// environment variable and the variable of the suite, undefined variables are an error.
+func substituteVars(content string, vars map[string]string) (string, error) {
+	var undefined error
+	content = templateVarPattern.ReplaceAllStringFunc(content, func(placeholder string) string {
+		match := templateVarPattern.FindStringSubmatch(placeholder)
+		var value string
+		var found bool
+		if match[1] == "Env" {
+			value, found = os.LookupEnv(match[2])
+		} else {
+			value, found = vars[match[2]]
+		}
+		if !found && undefined == nil {
+			undefined = fmt.Errorf("%s is not defined", placeholder)
+		}
+		return value
+	})
+	if undefined != nil {
+		return "", undefined
+	}
+
+	return envVarPattern.ReplaceAllStringFunc(content, func(placeholder string) string {
+		name := envVarPattern.FindStringSubmatch(placeholder)[1]
+		if value, found := vars[name]; found {
+			return value
+		}
+		if value, found := os.LookupEnv(name); found {
+			return value
+		}
+		return placeholder
+	}), nil
+}
+
+// substituteVarsInValue replaces the placeholders in the strings of a set value, including the strings in lists and
+// maps.
+func substituteVarsInValue(value interface{}, vars map[string]string) (interface{}, error) {
+	switch typed := value.(type) {
+	case string:
+		return substituteVars(typed, vars)
+	case []interface{}:
+		substituted := make([]interface{}, len(typed))
+		for idx, item := range typed {
+			substitutedItem, err := substituteVarsInValue(item, vars)
+			if err != nil {
+				return nil, err
+			}
+			substituted[idx] = substitutedItem
+		}
+		return substituted, nil
+	case map[string]interface{}:
+		substituted := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			substitutedItem, err := substituteVarsInValue(item, vars)
+			if err != nil {
+				return nil, err
+			}
+			substituted[key] = substitutedItem
+		}
+		return substituted, nil
+	}
+	return value, nil
+}
+
+// substituteVars replaces the placeholders in the content of values, when the suite declares vars or substituteEnv.
+// Otherwise the content is kept as is, as the placeholders can be part of a script or of a template rendered by tpl.
+func (t *TestJob) substituteVars(content string) (string, error) {
+	if !t.substitutesVars {
+		return content, nil
+	}
+	return substituteVars(content, t.vars)
+}
+
+// substituteVarsInValue replaces the placeholders in the set value, when the suite declares vars or substituteEnv.
+func (t *TestJob) substituteVarsInValue(value interface{}) (interface{}, error) {
+	if !t.substitutesVars {
+		return value, nil
+	}
+	return substituteVarsInValue(value, t.vars)
+}
@@ -0,0 +1,132 @@
+package unittest_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/helm-unittest/helm-unittest/pkg/unittest/printer"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/helm-unittest/helm-unittest/pkg/unittest"
+)
+
+func TestV3RunnerWithVars(t *testing.T) {
+	t.Setenv("HELM_UNITTEST_ENVIRONMENT", "prod")
+	chartPath := filepath.Join(t.TempDir(), "basic")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS(testV3BasicChart)))
+	assert.NoError(t, os.RemoveAll(filepath.Join(chartPath, "tests")))
+	assert.NoError(t, os.Mkdir(filepath.Join(chartPath, "tests"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "values.yaml"), []byte(`nameOverride: "{{ .Env.HELM_UNITTEST_ENVIRONMENT }}-${NAME}"
+image:
+  tag: "${TAG}"
+  repository: ${HELM_UNITTEST_UNDEFINED}
+`), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "deployment_test.yaml"), []byte(`suite: vars
+templates:
+  - configmap.yaml
+  - deployment.yaml
+values:
+  - values.yaml
+vars:
+  NAME: web
+  TAG: "1.10"
+tests:
+  - it: should substitute the variables
+    template: deployment.yaml
+    documentIndex: 0
+    asserts:
+      - equal:
+          path: metadata.name
+          value: RELEASE-NAME-prod-web
+      - equal:
+          path: spec.template.spec.containers[0].image
+          value: ${HELM_UNITTEST_UNDEFINED}:1.10
+  - it: should substitute the variables of set
+    template: deployment.yaml
+    documentIndex: 0
+    set:
+      image:
+        tag: "{{ .Vars.TAG }}-${HELM_UNITTEST_ENVIRONMENT}"
+    asserts:
+      - equal:
+          path: spec.template.spec.containers[0].image
+          value: ${HELM_UNITTEST_UNDEFINED}:1.10-prod
+  - it: should fail for undefined template variables
+    template: deployment.yaml
+    setString:
+      image.tag: "{{ .Vars.UNDEFINED }}"
+    asserts:
+      - hasDocuments:
+          count: 2
+`), 0644))
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/*_test.yaml"},
+	}
+	assert.False(t, runner.RunV3([]string{chartPath}))
+	assert.Contains(t, buffer.String(), "Tests:       1 failed, 1 errored, 2 passed, 3 total", buffer.String())
+	assert.Contains(t, buffer.String(), "failed to substitute setString image.tag: {{ .Vars.UNDEFINED }} is not defined")
+}
+
+func TestV3RunnerWithoutVarsKeepsThePlaceholders(t *testing.T) {
+	t.Setenv("HOME", "/home/runner")
+	chartPath := filepath.Join(t.TempDir(), "basic")
+	assert.NoError(t, os.CopyFS(chartPath, os.DirFS(testV3BasicChart)))
+	assert.NoError(t, os.RemoveAll(filepath.Join(chartPath, "tests")))
+	assert.NoError(t, os.Mkdir(filepath.Join(chartPath, "tests"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "values.yaml"), []byte(`image:
+  tag: "${HOME}"
+`), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartPath, "tests", "deployment_test.yaml"), []byte(`suite: without vars
+templates:
+  - configmap.yaml
+  - deployment.yaml
+values:
+  - values.yaml
+tests:
+  - it: should keep the placeholders of the values files
+    template: deployment.yaml
+    documentIndex: 0
+    asserts:
+      - matchRegex:
+          path: spec.template.spec.containers[0].image
+          pattern: :\$\{HOME\}$
+  - it: should keep the placeholders of set
+    template: deployment.yaml
+    documentIndex: 0
+    set:
+      image.tag: "{{ .Env.HOME }}"
+    asserts:
+      - matchRegex:
+          path: spec.template.spec.containers[0].image
+          pattern: :\{\{ \.Env\.HOME \}\}$
+---
+suite: substitute the environment
+templates:
+  - configmap.yaml
+  - deployment.yaml
+values:
+  - values.yaml
+substituteEnv: true
+tests:
+  - it: should substitute the environment variables
+    template: deployment.yaml
+    documentIndex: 0
+    asserts:
+      - matchRegex:
+          path: spec.template.spec.containers[0].image
+          pattern: :/home/runner$
+`), 0644))
+
+	buffer := new(bytes.Buffer)
+	runner := TestRunner{
+		Printer:   printer.NewPrinter(buffer, nil),
+		TestFiles: []string{"tests/*_test.yaml"},
+	}
+	assert.True(t, runner.RunV3([]string{chartPath}), buffer.String())
+	assert.Contains(t, buffer.String(), "Tests:       3 passed, 3 total")
+}
@@ -0,0 +1,9 @@
+// Package schema provides the JSON schema of the test suite files.
+package schema
+
+import _ "embed"
+
+// TestSuite the JSON schema of the test suite files
+//
+//go:embed helm-testsuite.json
+var TestSuite []byte